@@ -0,0 +1,303 @@
+package ru_nalog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/temoto/ru-nalog-go/cp866"
+)
+
+// FFD wire format: uint16 tag, uint16 length, value; all little-endian. Strings are CP866, see CheckCP866.
+const tlvHeaderSize = 4
+
+// Encodes TLV in FFD binary format, implements encoding.BinaryMarshaler.
+func (self *TLV) MarshalBinary() ([]byte, error) {
+	return self.appendBinary(make([]byte, 0, 64))
+}
+
+// Encodes document as STLV with document type as tag, implements encoding.BinaryMarshaler.
+func (d *Doc) MarshalBinary() ([]byte, error) {
+	if d == nil {
+		return nil, fmt.Errorf("Doc(nil).MarshalBinary()")
+	}
+	if d.Type == 0 {
+		return nil, fmt.Errorf("Doc.MarshalBinary #%d type=0", d.Number)
+	}
+	b := make([]byte, tlvHeaderSize, 512)
+	var err error
+	cs := d.Props.Children()
+	for i := range cs {
+		if b, err = cs[i].appendBinary(b); err != nil {
+			return nil, err
+		}
+	}
+	length := len(b) - tlvHeaderSize
+	if length > math.MaxUint16 {
+		return nil, fmt.Errorf("Doc.MarshalBinary #%d length=%d overflow", d.Number, length)
+	}
+	binary.LittleEndian.PutUint16(b[0:], uint16(d.Type))
+	binary.LittleEndian.PutUint16(b[2:], uint16(length))
+	return b, nil
+}
+
+func (self *TLV) appendBinary(b []byte) ([]byte, error) {
+	if self == nil {
+		return b, fmt.Errorf("TLV(nil).MarshalBinary()")
+	}
+	if err := self.Err(); err != nil {
+		return b, err
+	}
+	start := len(b)
+	b = append(b, 0, 0, 0, 0)
+	b, err := self.appendBinaryValue(b)
+	if err != nil {
+		return b[:start], err
+	}
+	length := len(b) - start - tlvHeaderSize
+	switch {
+	case length > math.MaxUint16:
+		err = fmt.Errorf("MarshalBinary #%d length=%d overflow", self.Tag, length)
+	case self.Varlen && length > int(self.Length):
+		err = fmt.Errorf("MarshalBinary #%d length=%d max=%d", self.Tag, length, self.Length)
+	case !self.Varlen && length != int(self.Length):
+		err = fmt.Errorf("MarshalBinary #%d length=%d fixed=%d", self.Tag, length, self.Length)
+	}
+	if err != nil {
+		return b[:start], err
+	}
+	binary.LittleEndian.PutUint16(b[start:], uint16(self.Tag))
+	binary.LittleEndian.PutUint16(b[start+2:], uint16(length))
+	return b, nil
+}
+
+func (self *TLV) appendBinaryValue(b []byte) ([]byte, error) {
+	switch self.Kind {
+	case DataKindBool:
+		if self.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+
+	case DataKindBytes:
+		return append(b, self.Bytes()...), nil
+
+	case DataKindFVLN:
+		fvln, err := self.Decimal().MarshalBinary()
+		if err != nil {
+			return b, fmt.Errorf("MarshalBinary #%d %v", self.Tag, err)
+		}
+		return append(b, fvln...), nil
+
+	case DataKindSTLV:
+		var err error
+		cs := self.Children()
+		for i := range cs {
+			if b, err = cs[i].appendBinary(b); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+
+	case DataKindString:
+		s, err := encodeCP866(self.Tag, self.String())
+		if err != nil {
+			return b, err
+		}
+		b = append(b, s...)
+		if !self.Varlen {
+			// fixed strings are right padded with spaces, see FixedString()
+			if pad := int(self.Length) - len(s); pad > 0 {
+				b = append(b, strings.Repeat(" ", pad)...)
+			}
+		}
+		return b, nil
+
+	case DataKindTime:
+		unix := wallClockUnix(self.Time())
+		if unix < 0 || unix > math.MaxUint32 {
+			return b, fmt.Errorf("MarshalBinary #%d unixtime=%d overflow", self.Tag, unix)
+		}
+		var buf [4]byte
+		binary.LittleEndian.PutUint32(buf[:], uint32(unix))
+		return append(b, buf[:]...), nil
+
+	case DataKindUint:
+		n, _ := toUint64(self.value)
+		if self.Length < 8 && n>>(8*self.Length) != 0 {
+			return b, fmt.Errorf("MarshalBinary #%d value=%d overflow length=%d", self.Tag, n, self.Length)
+		}
+		for i := uint16(0); i < self.Length; i++ {
+			b = append(b, byte(n>>(8*i)))
+		}
+		return b, nil
+
+	case DataKindVLN:
+		return appendVLN(b, self.Uint64()), nil
+	}
+	return b, fmt.Errorf("MarshalBinary #%d unhandled kind=%s", self.Tag, self.Kind.String())
+}
+
+// Little-endian, minimal length, at least one byte.
+func appendVLN(b []byte, n uint64) []byte {
+	for {
+		b = append(b, byte(n))
+		n >>= 8
+		if n == 0 {
+			return b
+		}
+	}
+}
+
+// Error in FFD binary data, Offset is relative to the start of decoded buffer.
+type DecodeError struct {
+	Offset int
+	Tag    Tag
+	Msg    string
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("decode offset=%d tag=%d %s", e.Offset, e.Tag, e.Msg)
+}
+
+// Decodes exactly one TLV occupying whole b, tags are looked up in DefaultTags.
+func ParseTLV(b []byte) (*TLV, error) {
+	return DefaultTags.ParseTLV(b)
+}
+
+// Implements encoding.BinaryUnmarshaler.
+func (self *TLV) UnmarshalBinary(b []byte) error {
+	n, err := self.decodeBinary(b, 0)
+	if err != nil {
+		return err
+	}
+	if n != len(b) {
+		return &DecodeError{Offset: n, Tag: self.Tag, Msg: fmt.Sprintf("trailing garbage length=%d", len(b)-n)}
+	}
+	return nil
+}
+
+// Implements encoding.BinaryUnmarshaler, tags are looked up in d.Tags. Document type is taken from top level tag, Number from 1040 if present.
+func (d *Doc) UnmarshalBinary(b []byte) error {
+	tag, value, err := decodeHeader(b, 0)
+	if err != nil {
+		return err
+	}
+	if tagsize := tlvHeaderSize + len(value); tagsize != len(b) {
+		return &DecodeError{Offset: tagsize, Tag: tag, Msg: fmt.Sprintf("trailing garbage length=%d", len(b)-tagsize)}
+	}
+	children, err := decodeChildren(d.Tags, value, tlvHeaderSize)
+	if err != nil {
+		return err
+	}
+	*d = Doc{Type: DocType(tag), Tags: d.Tags}
+	d.Props.tags = d.Tags
+	d.Props.Kind = DataKindSTLV
+	d.Props.value = children
+	if t := d.FindByTag(TagDocNumber); t != nil && t.Err() == nil {
+		d.Number = t.Uint32()
+	}
+	return nil
+}
+
+func decodeHeader(b []byte, offset int) (Tag, []byte, error) {
+	if len(b) < tlvHeaderSize {
+		return 0, nil, &DecodeError{Offset: offset, Msg: fmt.Sprintf("short header length=%d", len(b))}
+	}
+	tag := Tag(binary.LittleEndian.Uint16(b[0:]))
+	length := int(binary.LittleEndian.Uint16(b[2:]))
+	if len(b)-tlvHeaderSize < length {
+		return tag, nil, &DecodeError{Offset: offset, Tag: tag, Msg: fmt.Sprintf("length=%d exceeds available=%d", length, len(b)-tlvHeaderSize)}
+	}
+	return tag, b[tlvHeaderSize : tlvHeaderSize+length], nil
+}
+
+func decodeChildren(tags *TagRegistry, b []byte, offset int) ([]TLV, error) {
+	children := make([]TLV, 0, 8)
+	for pos := 0; pos < len(b); {
+		child := TLV{tags: tags}
+		n, err := child.decodeBinary(b[pos:], offset+pos)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+		pos += n
+	}
+	return children, nil
+}
+
+// Returns number of bytes consumed.
+func (self *TLV) decodeBinary(b []byte, offset int) (int, error) {
+	tag, value, err := decodeHeader(b, offset)
+	if err != nil {
+		return 0, err
+	}
+	tags := self.tags
+	desc := tags.Find(tag)
+	if desc == nil {
+		return 0, &DecodeError{Offset: offset, Tag: tag, Msg: "unknown tag"}
+	}
+	fail := func(format string, args ...interface{}) (int, error) {
+		return 0, &DecodeError{Offset: offset, Tag: tag, Msg: fmt.Sprintf(format, args...)}
+	}
+	switch {
+	case desc.Varlen && len(value) > int(desc.Length):
+		return fail("length=%d max=%d", len(value), desc.Length)
+	case !desc.Varlen && len(value) != int(desc.Length):
+		return fail("length=%d fixed=%d", len(value), desc.Length)
+	}
+
+	*self = TLV{TagDesc: *desc, tags: tags}
+	var v interface{}
+	switch desc.Kind {
+	case DataKindBool:
+		if len(value) != 1 || value[0] > 1 {
+			return fail("invalid bool=%x", value)
+		}
+		v = value[0] == 1
+	case DataKindBytes:
+		v = append([]byte(nil), value...)
+	case DataKindFVLN:
+		d := Decimal{}
+		if err := d.UnmarshalBinary(value); err != nil {
+			return fail("%v", err)
+		}
+		v = d
+	case DataKindSTLV:
+		children, err := decodeChildren(tags, value, offset+tlvHeaderSize)
+		if err != nil {
+			return 0, err
+		}
+		v = children
+	case DataKindString:
+		v = cp866.Decode(value)
+	case DataKindTime:
+		if len(value) != 4 {
+			return fail("invalid unixtime length=%d", len(value))
+		}
+		v = time.Unix(int64(binary.LittleEndian.Uint32(value)), 0).UTC()
+	case DataKindUint, DataKindVLN:
+		if len(value) > 8 {
+			return fail("invalid integer length=%d", len(value))
+		}
+		v = decodeVLN(value)
+	default:
+		return fail("unhandled kind=%s", desc.Kind.String())
+	}
+	self.SetValue(v)
+	if err := self.Err(); err != nil {
+		return fail("%v", err)
+	}
+	return tlvHeaderSize + len(value), nil
+}
+
+// Little-endian unsigned integer of any length up to 8 bytes.
+func decodeVLN(b []byte) uint64 {
+	var n uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		n = n<<8 | uint64(b[i])
+	}
+	return n
+}
@@ -0,0 +1,147 @@
+package ru_nalog
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	type Case struct {
+		tag    Tag
+		value  interface{}
+		expect string
+	}
+	cases := []Case{
+		{1001, true, "e9030100" + "01"},
+		{1012, time.Unix(0x5e2bb88b, 0).UTC(), "f4030400" + "8bb82b5e"},
+		// device wall clock, location is not counted
+		{1012, time.Date(2020, time.January, 25, 3, 39, 55, 0, time.FixedZone("MSK", 3*60*60)), "f4030400" + "8bb82b5e"},
+		{1018, "7725225244", "fa030c00" + "373732353232353234342020"},
+		{1020, 200, "fc030100" + "c8"},
+		{1020, 0, "fc030100" + "00"},
+		{1023, 1.5, "ff030200" + "010f"},
+		{1023, 22, "ff030200" + "0016"},
+		{1030, "item", "06040400" + "6974656d"},
+		{1054, 1, "1e040100" + "01"},
+		{1038, 372, "0e040400" + "74010000"},
+		{1077, []byte{1, 2, 3, 4, 5, 6}, "35040600" + "010203040506"},
+	}
+	for _, c := range cases {
+		tlv := NewTLV(c.tag)
+		tlv.SetValue(c.value)
+		b, err := tlv.MarshalBinary()
+		require.NoError(t, err, "tag=%d", c.tag)
+		assert.Equal(t, c.expect, hex.EncodeToString(b), "tag=%d", c.tag)
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		tlv := NewTLV(1018)
+		tlv.SetValue("7725225244123")
+		_, err := tlv.MarshalBinary()
+		assert.Error(t, err)
+
+		tlv = NewTLV(1054)
+		tlv.SetValue(256)
+		_, err = tlv.MarshalBinary()
+		assert.Error(t, err)
+
+		tlv = NewTLV(1077)
+		tlv.SetValue([]byte{1})
+		_, err = tlv.MarshalBinary()
+		assert.Error(t, err)
+	})
+}
+
+func TestDocMarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	d := NewDoc(0, FDCheck)
+	d.AppendNew(1054, 1)
+	row := d.AppendNew(1059, nil)
+	row.AppendNew(1023, 1)
+	row.AppendNew(1079, 7)
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, "03001400"+"1e04010001"+"23040b00"+"ff0302000001"+"3704010007", hex.EncodeToString(b))
+
+	_, err = NewDoc(0, 0).MarshalBinary()
+	assert.Error(t, err)
+}
+
+func TestParseTLV(t *testing.T) {
+	t.Parallel()
+
+	for _, tag := range []Tag{1001, 1012, 1018, 1020, 1023, 1030, 1038, 1054, 1077} {
+		tlv := NewTLV(tag)
+		switch tlv.Kind {
+		case DataKindBool:
+			tlv.SetValue(true)
+		case DataKindBytes:
+			tlv.SetValue([]byte("\x00\x01\x02\x03\x04\x05"))
+		case DataKindFVLN:
+			tlv.SetValue(1.25)
+		case DataKindString:
+			tlv.SetValue("7725225244")
+		case DataKindTime:
+			tlv.SetValue(time.Unix(1579922299, 0).UTC())
+		default:
+			tlv.SetValue(37)
+		}
+		b, err := tlv.MarshalBinary()
+		require.NoError(t, err)
+		parsed, err := ParseTLV(b)
+		require.NoError(t, err, "tag=%d", tag)
+		assert.Equal(t, tlv.GoString(), parsed.GoString())
+		if tlv.Kind == DataKindTime {
+			assert.Equal(t, time.UTC, parsed.Time().Location())
+		}
+	}
+
+	type ErrCase struct {
+		name   string
+		input  string
+		offset int
+	}
+	for _, c := range []ErrCase{
+		{"short-header", "1e04", 0},
+		{"short-value", "1e040200" + "01", 0},
+		{"unknown-tag", "01000100" + "01", 0},
+		{"trailing", "1e040100" + "01" + "00", 5},
+		{"fixed-length", "35040100" + "01", 0},
+		{"invalid-bool", "e9030100" + "02", 0},
+		{"nested", "23040a00" + "1e040100" + "01" + "e9030100" + "07", 9},
+	} {
+		b, err := hex.DecodeString(c.input)
+		require.NoError(t, err)
+		_, err = ParseTLV(b)
+		require.Error(t, err, c.name)
+		if de, ok := err.(*DecodeError); assert.True(t, ok, c.name) {
+			assert.Equal(t, c.offset, de.Offset, c.name)
+		}
+	}
+}
+
+func TestDocUnmarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	d1 := NewDoc(0, FDCheck)
+	d1.AppendNew(1054, 1)
+	d1.AppendNew(1040, 8493)
+	row := d1.AppendNew(1059, nil)
+	row.AppendNew(1023, 1.5)
+	row.AppendNew(1030, "item")
+	row.AppendNew(1079, 7)
+	b, err := d1.MarshalBinary()
+	require.NoError(t, err)
+
+	d2 := &Doc{}
+	require.NoError(t, d2.UnmarshalBinary(b))
+	d1.Number = 8493
+	assert.Equal(t, d1.String(), d2.String())
+}
@@ -0,0 +1,71 @@
+package ru_nalog
+
+import (
+	"fmt"
+
+	"github.com/temoto/ru-nalog-go/cp866"
+)
+
+// FFD binary strings are CP866. MarshalBinary fails on characters absent in code page,
+// CheckCP866 finds them beforehand, ReplaceNonCP866 applies replacement policy of cp866.Encode:
+// typographic quotes, dashes and spaces become look-alikes, anything else (emoji, Latin letters with diacritics) becomes '?'.
+
+// String value is not representable in CP866.
+type CP866Error struct {
+	Tag    Tag
+	Path   string // see childPaths, empty from MarshalBinary
+	Rune   rune
+	Offset int // bytes in UTF-8 value
+}
+
+func (e *CP866Error) Error() string {
+	where := fmt.Sprintf("tag=%d", e.Tag)
+	if e.Path != "" {
+		where = "path=" + e.Path
+	}
+	return fmt.Sprintf("%s character %q U+%04X offset=%d is not representable in CP866", where, e.Rune, e.Rune, e.Offset)
+}
+
+func encodeCP866(tag Tag, s string) ([]byte, error) {
+	b, err := cp866.EncodeStrict(s)
+	if err != nil {
+		e := err.(*cp866.EncodeError)
+		return nil, &CP866Error{Tag: tag, Rune: e.Rune, Offset: e.Offset}
+	}
+	return b, nil
+}
+
+// Returns *CP866Error for the first string value not representable in CP866, including children.
+func (self *TLV) CheckCP866() error {
+	return self.walk("", func(path string, t *TLV) error {
+		if t.Kind != DataKindString || t.Err() != nil {
+			return nil
+		}
+		if _, err := encodeCP866(t.Tag, t.String()); err != nil {
+			err.(*CP866Error).Path = path
+			return err
+		}
+		return nil
+	})
+}
+
+// Replaces characters absent in CP866 in all string values, including children.
+// Character count is preserved, so values stay within TagDesc.Length. Returns number of changed values.
+func (self *TLV) ReplaceNonCP866() int {
+	n := 0
+	_ = self.walk("", func(_ string, t *TLV) error {
+		s, ok := t.value.(string)
+		if t.Kind != DataKindString || !ok {
+			return nil
+		}
+		if replaced := cp866.Decode(cp866.Encode(s)); replaced != s {
+			t.SetValue(replaced)
+			n++
+		}
+		return nil
+	})
+	return n
+}
+
+func (d *Doc) CheckCP866() error    { return d.Props.CheckCP866() }
+func (d *Doc) ReplaceNonCP866() int { return d.Props.ReplaceNonCP866() }
@@ -0,0 +1,46 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinaryCP866(t *testing.T) {
+	t.Parallel()
+
+	tlv := NewTLV(TagItemName)
+	tlv.SetValue("Чай №1")
+	b, err := tlv.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x06, 0x04, 0x06, 0x00, 0x97, 0xa0, 0xa9, ' ', 0xfc, '1'}, b)
+	parsed, err := ParseTLV(b)
+	require.NoError(t, err)
+	assert.Equal(t, "Чай №1", parsed.String())
+
+	// fixed length is counted in CP866 bytes
+	tlv = NewTLV(TagFNNumber)
+	tlv.SetValue("ФН")
+	b, err = tlv.MarshalBinary()
+	require.NoError(t, err)
+	assert.Len(t, b, 4+16)
+
+	d := newTestQueryDoc(t)
+	d.Get("1059[1]/1030").SetValue("кофе ☕ «латте»")
+	_, err = d.MarshalBinary()
+	if assert.IsType(t, &CP866Error{}, err) {
+		assert.Equal(t, &CP866Error{Tag: TagItemName, Rune: '☕', Offset: 9}, err)
+		assert.Equal(t, `tag=1030 character '☕' U+2615 offset=9 is not representable in CP866`, err.Error())
+	}
+	err = d.CheckCP866()
+	assert.Equal(t, &CP866Error{Tag: TagItemName, Path: "1059[1]/1030", Rune: '☕', Offset: 9}, err)
+	assert.Contains(t, err.Error(), "path=1059[1]/1030 ")
+
+	assert.Equal(t, 1, d.ReplaceNonCP866())
+	assert.Equal(t, `кофе ? "латте"`, d.Get("1059[1]/1030").String())
+	assert.NoError(t, d.CheckCP866())
+	assert.Equal(t, 0, d.ReplaceNonCP866())
+	_, err = d.MarshalBinary()
+	assert.NoError(t, err)
+}
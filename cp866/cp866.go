@@ -0,0 +1,101 @@
+// Package cp866 converts between UTF-8 and DOS Cyrillic code page 866 (IBM866),
+// used by FFD binary strings and thermal printers.
+package cp866
+
+import "fmt"
+
+// Byte for characters absent in code page.
+const Replacement = '?'
+
+// Character absent in code page, Offset is in bytes of UTF-8 string.
+// Invalid UTF-8 is reported as U+FFFD.
+type EncodeError struct {
+	Rune   rune
+	Offset int
+}
+
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("cp866: character %q U+%04X offset=%d is not representable", e.Rune, e.Rune, e.Offset)
+}
+
+// Upper half of code page, 0x00-0x7f is ASCII.
+var decodeTable = [128]rune{
+	'А', 'Б', 'В', 'Г', 'Д', 'Е', 'Ж', 'З', 'И', 'Й', 'К', 'Л', 'М', 'Н', 'О', 'П',
+	'Р', 'С', 'Т', 'У', 'Ф', 'Х', 'Ц', 'Ч', 'Ш', 'Щ', 'Ъ', 'Ы', 'Ь', 'Э', 'Ю', 'Я',
+	'а', 'б', 'в', 'г', 'д', 'е', 'ж', 'з', 'и', 'й', 'к', 'л', 'м', 'н', 'о', 'п',
+	'░', '▒', '▓', '│', '┤', '╡', '╢', '╖', '╕', '╣', '║', '╗', '╝', '╜', '╛', '┐',
+	'└', '┴', '┬', '├', '─', '┼', '╞', '╟', '╚', '╔', '╩', '╦', '╠', '═', '╬', '╧',
+	'╨', '╤', '╥', '╙', '╘', '╒', '╓', '╫', '╪', '┘', '┌', '█', '▄', '▌', '▐', '▀',
+	'р', 'с', 'т', 'у', 'ф', 'х', 'ц', 'ч', 'ш', 'щ', 'ъ', 'ы', 'ь', 'э', 'ю', 'я',
+	'Ё', 'ё', 'Є', 'є', 'Ї', 'ї', 'Ў', 'ў', '°', '∙', '·', '√', '№', '¤', '■', '\u00a0',
+}
+
+var encodeTable = func() map[rune]byte {
+	m := make(map[rune]byte, len(decodeTable))
+	for i, r := range decodeTable {
+		m[r] = byte(0x80 + i)
+	}
+	return m
+}()
+
+// Look-alikes for common typography absent in code page, used by Encode.
+var similar = map[rune]byte{
+	'«': '"', '»': '"', '„': '"', '“': '"', '”': '"',
+	'‘': '\'', '’': '\'',
+	'‐': '-', '‑': '-', '–': '-', '—': '-', '−': '-',
+	'\u2007': 0xff, '\u202f': 0xff, // figure and narrow no-break spaces
+	'…': '.', '•': 0xf9,
+}
+
+// Single byte of r, false if code page has no such character.
+func EncodeRune(r rune) (byte, bool) {
+	if r < 0x80 {
+		return byte(r), r >= 0
+	}
+	b, ok := encodeTable[r]
+	return b, ok
+}
+
+// Strict conversion, error for the first character absent in code page.
+func EncodeStrict(s string) ([]byte, error) {
+	out := make([]byte, 0, len(s))
+	for i, r := range s {
+		b, ok := EncodeRune(r)
+		if !ok {
+			return nil, &EncodeError{Rune: r, Offset: i}
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+func DecodeByte(b byte) rune {
+	if b < 0x80 {
+		return rune(b)
+	}
+	return decodeTable[b-0x80]
+}
+
+// Lenient conversion for printing: typographic quotes and dashes become ASCII,
+// other characters absent in code page (and invalid UTF-8) become Replacement.
+func Encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if b, ok := EncodeRune(r); ok {
+			out = append(out, b)
+		} else if b, ok := similar[r]; ok {
+			out = append(out, b)
+		} else {
+			out = append(out, Replacement)
+		}
+	}
+	return out
+}
+
+func Decode(b []byte) string {
+	rs := make([]rune, len(b))
+	for i, c := range b {
+		rs[i] = DecodeByte(c)
+	}
+	return string(rs)
+}
@@ -0,0 +1,50 @@
+package cp866
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundtrip(t *testing.T) {
+	t.Parallel()
+
+	all := make([]byte, 256)
+	for i := range all {
+		all[i] = byte(i)
+	}
+	s := Decode(all)
+	assert.Equal(t, all, Encode(s))
+	require.NoError(t, quick.Check(func(b []byte) bool { return string(b) == string(Encode(Decode(b))) }, nil))
+
+	assert.Equal(t, []byte{0x8a, 0xa0, 0xe1, 0xe1, 0xa0, ' ', 0xfc, '1'}, Encode("Касса №1"))
+	assert.Equal(t, "Ёлка ёж", Decode([]byte{0xf0, 0xab, 0xaa, 0xa0, ' ', 0xf1, 0xa6}))
+}
+
+func TestEncodeLenient(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"чай" - 1 ?`, Decode(Encode("«чай» — 1 🍵")))
+	assert.Equal(t, "??", Decode(Encode("é\xff")))
+	_, ok := EncodeRune('é')
+	assert.False(t, ok)
+	b, ok := EncodeRune('Я')
+	assert.True(t, ok)
+	assert.Equal(t, byte(0x9f), b)
+}
+
+func TestEncodeStrict(t *testing.T) {
+	t.Parallel()
+
+	b, err := EncodeStrict("Ёж №1")
+	assert.NoError(t, err)
+	assert.Equal(t, Encode("Ёж №1"), b)
+
+	_, err = EncodeStrict("ёж — 1")
+	assert.Equal(t, &EncodeError{Rune: '—', Offset: 5}, err)
+	_, err = EncodeStrict("a\xff")
+	assert.Equal(t, &EncodeError{Rune: '�', Offset: 1}, err)
+	assert.Equal(t, `cp866: character '�' U+FFFD offset=1 is not representable`, err.Error())
+}
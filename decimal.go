@@ -0,0 +1,186 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Fixed point unsigned decimal, exact representation of FVLN.
+// Value = Mantissa / 10^Point. Point is kept as is, so 1.000 and 1 are different encodings of equal values.
+type Decimal struct {
+	Mantissa uint64
+	Point    uint8
+}
+
+// FVLN: one byte of point position, up to 7 bytes of mantissa.
+const maxFVLNMantissa = 1<<56 - 1
+
+func NewDecimal(mantissa uint64, point uint8) Decimal {
+	return Decimal{Mantissa: mantissa, Point: point}
+}
+
+// Accepts "1333.5", "1 333,500", "22". Spaces (including no-break) are ignored,
+// either dot or comma is decimal point. Trailing zeros are kept in Point.
+func ParseDecimal(s string) (Decimal, error) {
+	clean := make([]byte, 0, len(s))
+	point := -1
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			clean = append(clean, byte(r))
+		case r == '.' || r == ',':
+			if point >= 0 {
+				return Decimal{}, fmt.Errorf("ParseDecimal s=%q multiple decimal points", s)
+			}
+			point = len(clean)
+		case r == ' ' || r == '\u00a0' || r == '\u202f' || r == '\t':
+		default:
+			return Decimal{}, fmt.Errorf("ParseDecimal s=%q invalid character=%q", s, r)
+		}
+	}
+	if len(clean) == 0 {
+		return Decimal{}, fmt.Errorf("ParseDecimal s=%q no digits", s)
+	}
+	d := Decimal{}
+	if point >= 0 {
+		if len(clean)-point > math.MaxUint8 {
+			return Decimal{}, fmt.Errorf("ParseDecimal s=%q too precise", s)
+		}
+		d.Point = uint8(len(clean) - point)
+	}
+	m, err := strconv.ParseUint(string(clean), 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("ParseDecimal s=%q overflow", s)
+	}
+	d.Mantissa = m
+	return d, nil
+}
+
+// Shortest decimal representation that converts back to exactly f.
+func DecimalFromFloat64(f float64) (Decimal, error) {
+	if f < 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("DecimalFromFloat64 invalid value=%v", f)
+	}
+	return ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+// "1333.500"
+func (d Decimal) String() string { return d.Format(".", "") }
+
+// Format(",", " ") -> "1 333,500"
+func (d Decimal) Format(point, group string) string {
+	digits := strconv.FormatUint(d.Mantissa, 10)
+	if pad := int(d.Point) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-int(d.Point)], digits[len(digits)-int(d.Point):]
+	b := strings.Builder{}
+	for i := range intPart {
+		if i != 0 && group != "" && (len(intPart)-i)%3 == 0 {
+			b.WriteString(group)
+		}
+		b.WriteByte(intPart[i])
+	}
+	if d.Point != 0 {
+		b.WriteString(point)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// Nearest float64, may lose precision.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(fmt.Sprintf("%de-%d", d.Mantissa, d.Point), 64)
+	return f
+}
+
+func (d Decimal) IsZero() bool { return d.Mantissa == 0 }
+
+// Changes Point without loss of precision, error if value can not be represented.
+func (d Decimal) Rescale(point uint8) (Decimal, error) {
+	m := d.Mantissa
+	for p := d.Point; p < point; p++ {
+		if m > math.MaxUint64/10 {
+			return d, fmt.Errorf("Decimal.Rescale %s point=%d overflow", d.String(), point)
+		}
+		m *= 10
+	}
+	for p := d.Point; p > point; p-- {
+		if m%10 != 0 {
+			return d, fmt.Errorf("Decimal.Rescale %s point=%d loses precision", d.String(), point)
+		}
+		m /= 10
+	}
+	return Decimal{Mantissa: m, Point: point}, nil
+}
+
+// Returns -1, 0, +1 comparing values regardless of Point.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d.bigScaled(other.Point), other.bigScaled(d.Point)
+	return a.Cmp(b)
+}
+
+// Same value without trailing fractional zeros: 1.000 -> 1.
+func (d Decimal) normalize() Decimal {
+	for d.Point > 0 && d.Mantissa%10 == 0 {
+		d.Mantissa /= 10
+		d.Point--
+	}
+	return d
+}
+
+// Mantissa * 10^extra
+func (d Decimal) bigScaled(extra uint8) *big.Int {
+	n := new(big.Int).SetUint64(d.Mantissa)
+	return n.Mul(n, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(extra)), nil))
+}
+
+// FVLN binary encoding, implements encoding.BinaryMarshaler.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	if d.Mantissa > maxFVLNMantissa {
+		return nil, fmt.Errorf("FVLN %s mantissa overflow", d.String())
+	}
+	return appendVLN([]byte{d.Point}, d.Mantissa), nil
+}
+
+// Implements encoding.BinaryUnmarshaler.
+func (d *Decimal) UnmarshalBinary(b []byte) error {
+	if len(b) < 1 || len(b) > 8 {
+		return fmt.Errorf("FVLN invalid length=%d", len(b))
+	}
+	d.Point, d.Mantissa = b[0], decodeVLN(b[1:])
+	return nil
+}
+
+func toDecimal(v interface{}) interface{} {
+	switch x := v.(type) {
+	case Decimal:
+		return x
+	case *Decimal:
+		if x == nil {
+			return fmt.Errorf("toDecimal v=nil")
+		}
+		return *x
+	case float32:
+		return toDecimal(float64(x))
+	case float64:
+		if d, err := DecimalFromFloat64(x); err != nil {
+			return err
+		} else {
+			return d
+		}
+	case string:
+		if d, err := ParseDecimal(x); err != nil {
+			return err
+		} else {
+			return d
+		}
+	}
+	if n, ok := toUint64(v); ok {
+		return Decimal{Mantissa: n}
+	}
+	return fmt.Errorf("toDecimal v=%#v", v)
+}
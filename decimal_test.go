@@ -0,0 +1,89 @@
+package ru_nalog
+
+import (
+	"encoding/hex"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+
+	type Case struct {
+		input  string
+		expect Decimal
+		str    string
+	}
+	for _, c := range []Case{
+		{"0", Decimal{0, 0}, "0"},
+		{"22", Decimal{22, 0}, "22"},
+		{"22,000", Decimal{22000, 3}, "22.000"},
+		{"1 333,500", Decimal{1333500, 3}, "1333.500"},
+		{"1 333.5", Decimal{13335, 1}, "1333.5"},
+		{".25", Decimal{25, 2}, "0.25"},
+		{"0.007", Decimal{7, 3}, "0.007"},
+	} {
+		d, err := ParseDecimal(c.input)
+		require.NoError(t, err, c.input)
+		assert.Equal(t, c.expect, d, c.input)
+		assert.Equal(t, c.str, d.String(), c.input)
+	}
+	for _, input := range []string{"", " ", "1.2.3", "-1", "1e3", "99999999999999999999"} {
+		_, err := ParseDecimal(input)
+		assert.Error(t, err, input)
+	}
+
+	require.NoError(t, quick.Check(func(m uint64, p uint8) bool {
+		d := Decimal{Mantissa: m, Point: p % 20}
+		d2, err := ParseDecimal(d.Format(",", " "))
+		return assert.NoError(t, err) && assert.Equal(t, d, d2)
+	}, nil))
+}
+
+func TestDecimalFormat(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1 333,500", Decimal{1333500, 3}.Format(",", " "))
+	assert.Equal(t, "333,5", Decimal{3335, 1}.Format(",", " "))
+	assert.Equal(t, "1 000 000", Decimal{1000000, 0}.Format(",", " "))
+	assert.Equal(t, "0,001", Decimal{1, 3}.Format(",", " "))
+}
+
+func TestDecimalArith(t *testing.T) {
+	t.Parallel()
+
+	d, err := Decimal{1, 0}.Rescale(3)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal{1000, 3}, d)
+	d, err = Decimal{22000, 3}.Rescale(0)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal{22, 0}, d)
+	_, err = Decimal{22500, 3}.Rescale(0)
+	assert.Error(t, err)
+
+	assert.Equal(t, 0, Decimal{1, 0}.Cmp(Decimal{1000, 3}))
+	assert.Equal(t, -1, Decimal{999, 3}.Cmp(Decimal{1, 0}))
+	assert.Equal(t, 1, Decimal{2, 0}.Cmp(Decimal{1999, 3}))
+
+	f, err := DecimalFromFloat64(0.1)
+	require.NoError(t, err)
+	assert.Equal(t, Decimal{1, 1}, f)
+	assert.Equal(t, 0.1, f.Float64())
+}
+
+func TestDecimalBinary(t *testing.T) {
+	t.Parallel()
+
+	b, err := Decimal{1333500, 3}.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, "03fc5814", hex.EncodeToString(b))
+	var d Decimal
+	require.NoError(t, d.UnmarshalBinary(b))
+	assert.Equal(t, Decimal{1333500, 3}, d)
+
+	_, err = Decimal{1 << 56, 0}.MarshalBinary()
+	assert.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ChangeKind uint8
+
+const (
+	ChangeAdded   ChangeKind = iota + 1 // present only in b
+	ChangeRemoved                       // present only in a
+	ChangeChanged                       // value differs
+)
+
+var changeKindNames = [...]string{
+	ChangeAdded:   "added",
+	ChangeRemoved: "removed",
+	ChangeChanged: "changed",
+}
+
+func (k ChangeKind) String() string {
+	if k == 0 || int(k) >= len(changeKindNames) {
+		return fmt.Sprintf("ChangeKind(%d)", k)
+	}
+	return changeKindNames[k]
+}
+
+type Change struct {
+	Kind ChangeKind
+	Tag  Tag
+	Path string // "1059[1]/1023", index is present when a or b has several siblings with tag
+	A, B *TLV   // nil for added and removed respectively
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("added %s %s", c.Path, c.B.GoString())
+	case ChangeRemoved:
+		return fmt.Sprintf("removed %s %s", c.Path, c.A.GoString())
+	}
+	return fmt.Sprintf("changed %s %s -> %s", c.Path, c.A.GoString(), c.B.GoString())
+}
+
+type Changes []Change
+
+func (cs Changes) String() string {
+	ss := make([]string, len(cs))
+	for i, c := range cs {
+		ss[i] = c.String()
+	}
+	return strings.Join(ss, "\n")
+}
+
+// Compares properties of two documents. Siblings are matched by tag and order among same tag.
+// Caption and Printable are ignored, values are compared by meaning:
+// 1 and 1.000 FVLN, fixed string padding, Uint and VLN with same number are equal.
+// Document Number and Type are not compared. Nil document has no properties.
+func Diff(a, b *Doc) Changes {
+	var as, bs []TLV
+	if a != nil {
+		as = a.Props.Children()
+	}
+	if b != nil {
+		bs = b.Props.Children()
+	}
+	var cs Changes
+	diffChildren(&cs, "", as, bs)
+	return cs
+}
+
+func diffChildren(cs *Changes, prefix string, as, bs []TLV) {
+	countA, countB := tagCounts(as), tagCounts(bs)
+	path := func(tag Tag, idx int) string {
+		if countA[tag] > 1 || countB[tag] > 1 {
+			return fmt.Sprintf("%s%d[%d]", prefix, tag, idx)
+		}
+		return fmt.Sprintf("%s%d", prefix, tag)
+	}
+	seenA := make(map[Tag]int, len(countA))
+	for i := range as {
+		a := &as[i]
+		idx := seenA[a.Tag]
+		seenA[a.Tag]++
+		p := path(a.Tag, idx)
+		b := nthChild(bs, a.Tag, idx)
+		switch {
+		case b == nil:
+			*cs = append(*cs, Change{Kind: ChangeRemoved, Tag: a.Tag, Path: p, A: a})
+		case a.Kind == DataKindSTLV && b.Kind == DataKindSTLV:
+			diffChildren(cs, p+"/", a.Children(), b.Children())
+		case !equalValues(a, b):
+			*cs = append(*cs, Change{Kind: ChangeChanged, Tag: a.Tag, Path: p, A: a, B: b})
+		}
+	}
+	seenB := make(map[Tag]int, len(countB))
+	for i := range bs {
+		b := &bs[i]
+		idx := seenB[b.Tag]
+		seenB[b.Tag]++
+		if idx >= countA[b.Tag] {
+			*cs = append(*cs, Change{Kind: ChangeAdded, Tag: b.Tag, Path: path(b.Tag, idx), B: b})
+		}
+	}
+}
+
+func tagCounts(ts []TLV) map[Tag]int {
+	m := make(map[Tag]int, len(ts))
+	for i := range ts {
+		m[ts[i].Tag]++
+	}
+	return m
+}
+
+func nthChild(ts []TLV, tag Tag, n int) *TLV {
+	for i := range ts {
+		if ts[i].Tag == tag {
+			if n == 0 {
+				return &ts[i]
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+func equalValues(a, b *TLV) bool {
+	if (a.Kind == DataKindSTLV) != (b.Kind == DataKindSTLV) {
+		return false
+	}
+	return normalValue(a) == normalValue(b)
+}
+
+// Comparable representation of TLV value.
+func normalValue(t *TLV) interface{} {
+	switch v := t.value.(type) {
+	case error:
+		return "error: " + v.Error()
+	case Decimal:
+		return v.normalize()
+	case string:
+		return strings.TrimRightFunc(v, isSpace)
+	case []byte:
+		return string(v)
+	case time.Time:
+		return wallClockUnix(v)
+	}
+	if n, ok := toUint64(t.value); ok {
+		return n
+	}
+	return t.value
+}
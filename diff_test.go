@@ -0,0 +1,68 @@
+package ru_nalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	sent := newTestQueryDoc(t)
+	sent.AppendNew(1008, "e@ma.il")
+	sent.AppendNew(1018, "7725225244")
+	assert.Len(t, Diff(sent, sent.Clone()), 0)
+
+	got := sent.Clone()
+	got.Number = 8493
+	got.AppendNew(1012, time.Unix(1579922299, 0).UTC())
+	got.Get("1059[0]/1023").SetValue("1,000")
+	got.Get("1059[0]/1030").Printable = "a\t1"
+	got.Get("1059[1]/1079").SetValue(Money(201))
+	got.AppendNew(1059, nil).AppendNew(1030, "d")
+	got.Remove(1008)
+	// same number in other kind
+	n := &TLV{TagDesc: TagDesc{Kind: DataKindVLN, Tag: 1054, Length: 4, Varlen: true}}
+	n.SetValue(1)
+	got.Replace(0, n)
+	fixed := got.Get("1018")
+	fixed.Varlen = false
+
+	cs := Diff(sent, got)
+	require.Len(t, cs, 4, cs.String())
+	assert.Equal(t, Change{Kind: ChangeChanged, Tag: 1079, Path: "1059[1]/1079", A: sent.Get("1059[1]/1079"), B: got.Get("1059[1]/1079")}, cs[0])
+	assert.Equal(t, ChangeRemoved, cs[1].Kind)
+	assert.Equal(t, "1008", cs[1].Path)
+	assert.Nil(t, cs[1].B)
+	assert.Equal(t, "added 1012 (#1012 2020-01-25T03:18:19Z)", cs[2].String())
+	assert.Equal(t, "added 1059[3] (#1059 [(#1030 d)])", cs[3].String())
+	assert.Equal(t, "changed 1059[1]/1079 (#1079 200) -> (#1079 201)", cs[0].String())
+
+	// STLV against scalar
+	a, b := NewDoc(0, FDCheck), NewDoc(0, FDCheck)
+	a.AppendNew(1059, nil)
+	b.Props.Append(&TLV{TagDesc: TagDesc{Kind: DataKindString, Tag: 1059}, value: ""})
+	cs = Diff(a, b)
+	require.Len(t, cs, 1)
+	assert.Equal(t, ChangeChanged, cs[0].Kind)
+	assert.Equal(t, "changed", ChangeChanged.String())
+	assert.Equal(t, "ChangeKind(9)", ChangeKind(9).String())
+
+	// nil document has no properties
+	assert.Len(t, Diff(nil, nil), 0)
+	cs = Diff(nil, a)
+	require.Len(t, cs, 1)
+	assert.Equal(t, "added 1059 (#1059 [])", cs[0].String())
+	cs = Diff(a, nil)
+	require.Len(t, cs, 1)
+	assert.Equal(t, ChangeRemoved, cs[0].Kind)
+
+	// unixtime is compared by device wall clock, as encoded
+	a, b = NewDoc(0, FDCheck), NewDoc(0, FDCheck)
+	a.AppendNew(1012, time.Date(2020, time.January, 25, 3, 18, 19, 0, time.FixedZone("MSK", 3*60*60)))
+	b.AppendNew(1012, time.Unix(1579922299, 0).UTC())
+	assert.Len(t, Diff(a, b), 0)
+}
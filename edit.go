@@ -0,0 +1,149 @@
+package ru_nalog
+
+import "fmt"
+
+// Editing of STLV children. Methods return nil or false if self is not STLV or index is out of range.
+// Pointers to children obtained before editing may become stale.
+
+// Updates value of first child with tag or appends new one, see AppendNewE.
+// Updated child is replaced with new TLV, so Caption and Printable of old value are dropped.
+// Unknown enum value is returned as *EnumError. On error children are unchanged.
+func (self *TLV) Set(tag Tag, value interface{}) (*TLV, error) {
+	return self.set(self.tags, tag, value)
+}
+
+func (self *TLV) set(tags *TagRegistry, tag Tag, value interface{}) (*TLV, error) {
+	if self == nil {
+		return nil, fmt.Errorf("TLV(nil).Set #%d", tag)
+	}
+	if self.Kind != DataKindSTLV {
+		return nil, &NotSTLVError{Op: "Set", Tag: self.Tag, Kind: self.Kind}
+	}
+	// validate on scratch TLV, so invalid value does not replace good one
+	n := tags.NewTLV(tag)
+	if n == nil {
+		return nil, &UnknownTagError{Tag: tag}
+	}
+	if err := n.TrySetValue(value); err != nil {
+		return nil, err
+	}
+	if err := n.checkEnum(); err != nil {
+		return nil, err
+	}
+	if t := findChild(self, tag); t != nil {
+		*t = *n
+		return t, nil
+	}
+	return self.Append(n), nil
+}
+
+// Removes all children with tag, returns count.
+func (self *TLV) Remove(tag Tag) int {
+	cs := self.Children()
+	kept := cs[:0]
+	for i := range cs {
+		if cs[i].Tag != tag {
+			kept = append(kept, cs[i])
+		}
+	}
+	removed := len(cs) - len(kept)
+	if removed != 0 {
+		for i := len(kept); i < len(cs); i++ {
+			cs[i] = TLV{} // release references
+		}
+		self.value = kept
+	}
+	return removed
+}
+
+// Removes child at index i.
+func (self *TLV) RemoveAt(i int) bool {
+	cs := self.Children()
+	if i < 0 || i >= len(cs) {
+		return false
+	}
+	copy(cs[i:], cs[i+1:])
+	cs[len(cs)-1] = TLV{}
+	self.value = cs[:len(cs)-1]
+	return true
+}
+
+// Inserts copy of n before index i, i == len(children) appends.
+func (self *TLV) InsertAt(i int, n *TLV) *TLV {
+	if self == nil || n == nil || self.Kind != DataKindSTLV {
+		return nil
+	}
+	cs := self.Children()
+	if i < 0 || i > len(cs) {
+		return nil
+	}
+	cs = append(cs, TLV{})
+	copy(cs[i+1:], cs[i:])
+	cs[i] = *n
+	self.value = cs
+	return &cs[i]
+}
+
+// Puts copy of n at index i instead of existing child.
+func (self *TLV) Replace(i int, n *TLV) *TLV {
+	cs := self.Children()
+	if n == nil || i < 0 || i >= len(cs) {
+		return nil
+	}
+	cs[i] = *n
+	return &cs[i]
+}
+
+// Moves child from index to index, other children keep order.
+func (self *TLV) Move(from, to int) bool {
+	cs := self.Children()
+	if from < 0 || from >= len(cs) || to < 0 || to >= len(cs) {
+		return false
+	}
+	t := cs[from]
+	if from < to {
+		copy(cs[from:to], cs[from+1:to+1])
+	} else {
+		copy(cs[to+1:from+1], cs[to:from])
+	}
+	cs[to] = t
+	return true
+}
+
+// Deep copy, safe to edit independently of original.
+func (self *TLV) Clone() *TLV {
+	if self == nil {
+		return nil
+	}
+	c := *self
+	switch v := self.value.(type) {
+	case []TLV:
+		cs := make([]TLV, len(v), cap(v))
+		for i := range v {
+			cs[i] = *v[i].Clone()
+		}
+		c.value = cs
+	case []byte:
+		c.value = append([]byte(nil), v...)
+	}
+	return &c
+}
+
+// See TLV.Set
+func (d *Doc) Set(tag Tag, value interface{}) (*TLV, error) { return d.Props.set(d.Tags, tag, value) }
+
+func (d *Doc) Remove(tag Tag) int          { return d.Props.Remove(tag) }
+func (d *Doc) RemoveAt(i int) bool         { return d.Props.RemoveAt(i) }
+func (d *Doc) InsertAt(i int, n *TLV) *TLV { return d.Props.InsertAt(i, n) }
+func (d *Doc) Replace(i int, n *TLV) *TLV  { return d.Props.Replace(i, n) }
+func (d *Doc) Move(from, to int) bool      { return d.Props.Move(from, to) }
+
+// Deep copy of properties, TagRegistry is shared.
+func (d *Doc) Clone() *Doc {
+	if d == nil {
+		return nil
+	}
+	c := *d
+	c.Props = *d.Props.Clone()
+	return &c
+}
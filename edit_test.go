@@ -0,0 +1,105 @@
+package ru_nalog
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func childTags(ts []TLV) []Tag {
+	result := make([]Tag, len(ts))
+	for i := range ts {
+		result[i] = ts[i].Tag
+	}
+	return result
+}
+
+func TestEdit(t *testing.T) {
+	t.Parallel()
+
+	d := newTestQueryDoc(t)
+	_, err := d.Set(1054, CalcExpense)
+	require.NoError(t, err)
+	assert.Equal(t, CalcExpense, d.Get("1054").CalcSign())
+	_, err = d.Set(1008, "e@ma.il")
+	require.NoError(t, err)
+	assert.Equal(t, []Tag{1054, 1059, 1059, 1059, 1008}, childTags(d.Props.Children()))
+	_, err = d.Set(1054, 9)
+	assert.Error(t, err)
+	_, err = d.Set(1054, "income")
+	assert.Error(t, err)
+	assert.Equal(t, CalcExpense, d.Get("1054").CalcSign())
+	_, err = d.Set(1018, "7725225244123")
+	assert.IsType(t, &LengthError{}, err)
+	assert.Nil(t, d.Get("1018"))
+	_, err = d.Set(1, 1)
+	assert.Error(t, err)
+
+	// caption and printable text reported for old value are dropped
+	item := d.Get("1059[1]")
+	old := d.Get("1059[1]/1030")
+	old.Caption, old.Printable, old.PrintCaption = "Предмет расчета", "old name", "OLD"
+	renamed, err := item.Set(1030, "renamed")
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", d.Get("1059[1]/1030").String())
+	assert.Equal(t, "", renamed.Caption)
+	assert.Equal(t, "", renamed.Printable)
+	assert.Equal(t, *FindTag(1030), renamed.TagDesc)
+	_, err = d.Get("1054").Set(1030, "x")
+	assert.Error(t, err)
+
+	assert.Equal(t, 3, d.Remove(1059))
+	assert.Equal(t, 0, d.Remove(1059))
+	assert.Equal(t, []Tag{1054, 1008}, childTags(d.Props.Children()))
+
+	n := NewTLV(1055)
+	n.SetValue(TaxPatent)
+	assert.NotNil(t, d.InsertAt(1, n))
+	assert.NotNil(t, d.InsertAt(0, NewTLV(1021)))
+	assert.NotNil(t, d.InsertAt(4, NewTLV(1203)))
+	assert.Nil(t, d.InsertAt(6, n))
+	assert.Nil(t, d.InsertAt(-1, n))
+	assert.Equal(t, []Tag{1021, 1054, 1055, 1008, 1203}, childTags(d.Props.Children()))
+
+	assert.True(t, d.Move(0, 4))
+	assert.Equal(t, []Tag{1054, 1055, 1008, 1203, 1021}, childTags(d.Props.Children()))
+	assert.True(t, d.Move(3, 1))
+	assert.Equal(t, []Tag{1054, 1203, 1055, 1008, 1021}, childTags(d.Props.Children()))
+	assert.False(t, d.Move(0, 5))
+
+	r := d.Replace(1, NewTLV(1036))
+	require.NotNil(t, r)
+	assert.Equal(t, Tag(1036), r.Tag)
+	assert.Nil(t, d.Replace(5, n))
+	assert.True(t, d.RemoveAt(0))
+	assert.False(t, d.RemoveAt(4))
+	assert.Equal(t, []Tag{1036, 1055, 1008, 1021}, childTags(d.Props.Children()))
+}
+
+func TestClone(t *testing.T) {
+	t.Parallel()
+
+	template := newTestQueryDoc(t)
+	template.AppendNew(1077, []byte{1, 2, 3, 4, 5, 6})
+	before := template.String()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			d := template.Clone()
+			d.Get("1059[0]/1079").SetValue(Money(i))
+			d.Get("1059[1]").Set(1030, "changed")
+			d.Get("1077").Bytes()[0] = 0xff
+			d.Remove(1054)
+			d.Get("1059[2]").Remove(1199)
+			assert.NoError(t, d.ComputeTotals())
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, before, template.String())
+	assert.Nil(t, (*Doc)(nil).Clone())
+}
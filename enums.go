@@ -0,0 +1,554 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Enumerations and bit masks of fiscal attribute values.
+// String() is English, StringRu() is Russian as in FFD, Parse* accept both and decimal numbers.
+
+// 1054 признак расчета
+type CalcSign uint8
+
+const (
+	CalcIncome        CalcSign = 1 // приход
+	CalcIncomeReturn  CalcSign = 2 // возврат прихода
+	CalcExpense       CalcSign = 3 // расход
+	CalcExpenseReturn CalcSign = 4 // возврат расхода
+)
+
+var calcSignNames = []enumName{
+	CalcIncome:        {"income", "приход"},
+	CalcIncomeReturn:  {"income return", "возврат прихода"},
+	CalcExpense:       {"expense", "расход"},
+	CalcExpenseReturn: {"expense return", "возврат расхода"},
+}
+
+func (x CalcSign) Valid() bool      { return enumValid(calcSignNames, uint64(x)) }
+func (x CalcSign) String() string   { return enumString(calcSignNames, uint64(x), "CalcSign", false) }
+func (x CalcSign) StringRu() string { return enumString(calcSignNames, uint64(x), "CalcSign", true) }
+func ParseCalcSign(s string) (CalcSign, error) {
+	n, err := enumParse(calcSignNames, s, "CalcSign")
+	return CalcSign(n), err
+}
+
+// Bit mask: 1055 применяемая система налогообложения (single bit), 1062 системы налогообложения.
+type TaxSystem uint8
+
+const (
+	TaxCommon                  TaxSystem = 1 << iota // ОСН
+	TaxSimplifiedIncome                              // УСН доход
+	TaxSimplifiedIncomeExpense                       // УСН доход - расход
+	TaxImputedIncome                                 // ЕНВД
+	TaxAgricultural                                  // ЕСХН
+	TaxPatent                                        // ПСН
+)
+
+var taxSystemNames = []enumName{
+	{"common", "ОСН"},
+	{"simplified income", "УСН доход"},
+	{"simplified income-expense", "УСН доход - расход"},
+	{"imputed income", "ЕНВД"},
+	{"agricultural", "ЕСХН"},
+	{"patent", "ПСН"},
+}
+
+func (x TaxSystem) Valid() bool      { return maskValid(taxSystemNames, uint64(x)) }
+func (x TaxSystem) String() string   { return maskString(taxSystemNames, uint64(x), "TaxSystem", false) }
+func (x TaxSystem) StringRu() string { return maskString(taxSystemNames, uint64(x), "TaxSystem", true) }
+func ParseTaxSystem(s string) (TaxSystem, error) {
+	n, err := maskParse(taxSystemNames, s, "TaxSystem")
+	return TaxSystem(n), err
+}
+
+// 1199 ставка НДС
+type VATRate uint8
+
+const (
+	VAT20    VATRate = 1 // НДС 20%
+	VAT10    VATRate = 2 // НДС 10%
+	VAT20120 VATRate = 3 // НДС 20/120
+	VAT10110 VATRate = 4 // НДС 10/110
+	VAT0     VATRate = 5 // НДС 0%
+	VATNone  VATRate = 6 // без НДС
+)
+
+var vatRateNames = []enumName{
+	VAT20:    {"20%", "НДС 20%"},
+	VAT10:    {"10%", "НДС 10%"},
+	VAT20120: {"20/120", "НДС 20/120"},
+	VAT10110: {"10/110", "НДС 10/110"},
+	VAT0:     {"0%", "НДС 0%"},
+	VATNone:  {"none", "без НДС"},
+}
+
+func (x VATRate) Valid() bool      { return enumValid(vatRateNames, uint64(x)) }
+func (x VATRate) String() string   { return enumString(vatRateNames, uint64(x), "VATRate", false) }
+func (x VATRate) StringRu() string { return enumString(vatRateNames, uint64(x), "VATRate", true) }
+func ParseVATRate(s string) (VATRate, error) {
+	n, err := enumParse(vatRateNames, s, "VATRate")
+	return VATRate(n), err
+}
+
+// 1212 признак предмета расчета
+type PaymentSubject uint8
+
+const (
+	SubjectCommodity          PaymentSubject = 1  // товар
+	SubjectExcise             PaymentSubject = 2  // подакцизный товар
+	SubjectJob                PaymentSubject = 3  // работа
+	SubjectService            PaymentSubject = 4  // услуга
+	SubjectGamblingBet        PaymentSubject = 5  // ставка азартной игры
+	SubjectGamblingPrize      PaymentSubject = 6  // выигрыш азартной игры
+	SubjectLottery            PaymentSubject = 7  // лотерейный билет
+	SubjectLotteryPrize       PaymentSubject = 8  // выигрыш лотереи
+	SubjectIntellectual       PaymentSubject = 9  // предоставление РИД
+	SubjectPayment            PaymentSubject = 10 // платеж
+	SubjectAgentCommission    PaymentSubject = 11 // агентское вознаграждение
+	SubjectComposite          PaymentSubject = 12 // составной предмет расчета
+	SubjectAnother            PaymentSubject = 13 // иной предмет расчета
+	SubjectPropertyRight      PaymentSubject = 14 // имущественное право
+	SubjectNonOperatingIncome PaymentSubject = 15 // внереализационный доход
+	SubjectInsurancePremium   PaymentSubject = 16 // страховые взносы
+	SubjectSalesTax           PaymentSubject = 17 // торговый сбор
+	SubjectResortFee          PaymentSubject = 18 // курортный сбор
+	SubjectDeposit            PaymentSubject = 19 // залог
+	SubjectExpense            PaymentSubject = 20 // расход
+	SubjectPensionInsuranceIP PaymentSubject = 21 // взносы на ОПС ИП
+	SubjectPensionInsurance   PaymentSubject = 22 // взносы на ОПС
+	SubjectMedicalInsuranceIP PaymentSubject = 23 // взносы на ОМС ИП
+	SubjectMedicalInsurance   PaymentSubject = 24 // взносы на ОМС
+	SubjectSocialInsurance    PaymentSubject = 25 // взносы на ОСС
+	SubjectCasinoPayment      PaymentSubject = 26 // платеж казино
+)
+
+var paymentSubjectNames = []enumName{
+	SubjectCommodity:          {"commodity", "товар"},
+	SubjectExcise:             {"excise", "подакцизный товар"},
+	SubjectJob:                {"job", "работа"},
+	SubjectService:            {"service", "услуга"},
+	SubjectGamblingBet:        {"gambling bet", "ставка азартной игры"},
+	SubjectGamblingPrize:      {"gambling prize", "выигрыш азартной игры"},
+	SubjectLottery:            {"lottery", "лотерейный билет"},
+	SubjectLotteryPrize:       {"lottery prize", "выигрыш лотереи"},
+	SubjectIntellectual:       {"intellectual activity", "предоставление РИД"},
+	SubjectPayment:            {"payment", "платеж"},
+	SubjectAgentCommission:    {"agent commission", "агентское вознаграждение"},
+	SubjectComposite:          {"composite", "составной предмет расчета"},
+	SubjectAnother:            {"another", "иной предмет расчета"},
+	SubjectPropertyRight:      {"property right", "имущественное право"},
+	SubjectNonOperatingIncome: {"non-operating income", "внереализационный доход"},
+	SubjectInsurancePremium:   {"insurance premium", "страховые взносы"},
+	SubjectSalesTax:           {"sales tax", "торговый сбор"},
+	SubjectResortFee:          {"resort fee", "курортный сбор"},
+	SubjectDeposit:            {"deposit", "залог"},
+	SubjectExpense:            {"expense", "расход"},
+	SubjectPensionInsuranceIP: {"pension insurance IP", "взносы на ОПС ИП"},
+	SubjectPensionInsurance:   {"pension insurance", "взносы на ОПС"},
+	SubjectMedicalInsuranceIP: {"medical insurance IP", "взносы на ОМС ИП"},
+	SubjectMedicalInsurance:   {"medical insurance", "взносы на ОМС"},
+	SubjectSocialInsurance:    {"social insurance", "взносы на ОСС"},
+	SubjectCasinoPayment:      {"casino payment", "платеж казино"},
+}
+
+func (x PaymentSubject) Valid() bool { return enumValid(paymentSubjectNames, uint64(x)) }
+func (x PaymentSubject) String() string {
+	return enumString(paymentSubjectNames, uint64(x), "PaymentSubject", false)
+}
+func (x PaymentSubject) StringRu() string {
+	return enumString(paymentSubjectNames, uint64(x), "PaymentSubject", true)
+}
+func ParsePaymentSubject(s string) (PaymentSubject, error) {
+	n, err := enumParse(paymentSubjectNames, s, "PaymentSubject")
+	return PaymentSubject(n), err
+}
+
+// 1214 признак способа расчета
+type PaymentMethod uint8
+
+const (
+	MethodFullPrepayment PaymentMethod = 1 // предоплата 100%
+	MethodPrepayment     PaymentMethod = 2 // предоплата
+	MethodAdvance        PaymentMethod = 3 // аванс
+	MethodFullPayment    PaymentMethod = 4 // полный расчет
+	MethodPartialCredit  PaymentMethod = 5 // частичный расчет и кредит
+	MethodCreditTransfer PaymentMethod = 6 // передача в кредит
+	MethodCreditPayment  PaymentMethod = 7 // оплата кредита
+)
+
+var paymentMethodNames = []enumName{
+	MethodFullPrepayment: {"full prepayment", "предоплата 100%"},
+	MethodPrepayment:     {"prepayment", "предоплата"},
+	MethodAdvance:        {"advance", "аванс"},
+	MethodFullPayment:    {"full payment", "полный расчет"},
+	MethodPartialCredit:  {"partial payment and credit", "частичный расчет и кредит"},
+	MethodCreditTransfer: {"credit transfer", "передача в кредит"},
+	MethodCreditPayment:  {"credit payment", "оплата кредита"},
+}
+
+func (x PaymentMethod) Valid() bool { return enumValid(paymentMethodNames, uint64(x)) }
+func (x PaymentMethod) String() string {
+	return enumString(paymentMethodNames, uint64(x), "PaymentMethod", false)
+}
+func (x PaymentMethod) StringRu() string {
+	return enumString(paymentMethodNames, uint64(x), "PaymentMethod", true)
+}
+func ParsePaymentMethod(s string) (PaymentMethod, error) {
+	n, err := enumParse(paymentMethodNames, s, "PaymentMethod")
+	return PaymentMethod(n), err
+}
+
+// Bit mask: 1057 признак агента, 1222 признак агента по предмету расчета (single bit).
+type AgentFlags uint8
+
+const (
+	AgentBankPaying    AgentFlags = 1 << iota // банковский платежный агент
+	AgentBankPayingSub                        // банковский платежный субагент
+	AgentPaying                               // платежный агент
+	AgentPayingSub                            // платежный субагент
+	AgentAttorney                             // поверенный
+	AgentCommission                           // комиссионер
+	AgentAnother                              // иной агент
+)
+
+var agentFlagsNames = []enumName{
+	{"bank paying agent", "банковский платежный агент"},
+	{"bank paying subagent", "банковский платежный субагент"},
+	{"paying agent", "платежный агент"},
+	{"paying subagent", "платежный субагент"},
+	{"attorney", "поверенный"},
+	{"commission agent", "комиссионер"},
+	{"another agent", "иной агент"},
+}
+
+func (x AgentFlags) Valid() bool { return maskValid(agentFlagsNames, uint64(x)) }
+func (x AgentFlags) String() string {
+	return maskString(agentFlagsNames, uint64(x), "AgentFlags", false)
+}
+func (x AgentFlags) StringRu() string {
+	return maskString(agentFlagsNames, uint64(x), "AgentFlags", true)
+}
+func ParseAgentFlags(s string) (AgentFlags, error) {
+	n, err := maskParse(agentFlagsNames, s, "AgentFlags")
+	return AgentFlags(n), err
+}
+
+// 1101 код причины перерегистрации (FFD 1.0, 1.05)
+type ReregReason uint8
+
+const (
+	ReregFN       ReregReason = 1 // замена ФН
+	ReregOFD      ReregReason = 2 // замена ОФД
+	ReregUserData ReregReason = 3 // изменение реквизитов
+	ReregSettings ReregReason = 4 // изменение настроек ККТ
+)
+
+var reregReasonNames = []enumName{
+	ReregFN:       {"FN replacement", "замена ФН"},
+	ReregOFD:      {"OFD change", "замена ОФД"},
+	ReregUserData: {"user data change", "изменение реквизитов"},
+	ReregSettings: {"settings change", "изменение настроек ККТ"},
+}
+
+func (x ReregReason) Valid() bool { return enumValid(reregReasonNames, uint64(x)) }
+func (x ReregReason) String() string {
+	return enumString(reregReasonNames, uint64(x), "ReregReason", false)
+}
+func (x ReregReason) StringRu() string {
+	return enumString(reregReasonNames, uint64(x), "ReregReason", true)
+}
+func ParseReregReason(s string) (ReregReason, error) {
+	n, err := enumParse(reregReasonNames, s, "ReregReason")
+	return ReregReason(n), err
+}
+
+// Bit mask: 1205 коды причин изменения сведений о ККТ (FFD 1.1+)
+type ReregReasons uint32
+
+const (
+	ReregsFN              ReregReasons = 1 << 0 // замена ФН
+	ReregsOFD             ReregReasons = 1 << 1 // замена ОФД
+	ReregsUserName        ReregReasons = 1 << 2 // изменение наименования пользователя
+	ReregsAddress         ReregReasons = 1 << 3 // изменение адреса и (или) места расчетов
+	ReregsOnline          ReregReasons = 1 << 4 // перевод из автономного режима в режим передачи данных
+	ReregsOffline         ReregReasons = 1 << 5 // перевод из режима передачи данных в автономный режим
+	ReregsModel           ReregReasons = 1 << 6 // изменение версии модели ККТ
+	ReregsTaxSystems      ReregReasons = 1 << 7 // изменение перечня систем налогообложения
+	ReregsAutomat         ReregReasons = 1 << 8 // изменение номера автоматического устройства
+	ReregsFromAutomatic   ReregReasons = 1 << 9
+	ReregsToAutomatic     ReregReasons = 1 << 10
+	ReregsFromBSO         ReregReasons = 1 << 11
+	ReregsToBSO           ReregReasons = 1 << 12
+	ReregsFromInternet    ReregReasons = 1 << 13
+	ReregsToInternet      ReregReasons = 1 << 14
+	ReregsFromPayingAgent ReregReasons = 1 << 15
+	ReregsToPayingAgent   ReregReasons = 1 << 16
+	ReregsFromGambling    ReregReasons = 1 << 17
+	ReregsToGambling      ReregReasons = 1 << 18
+	ReregsFromLottery     ReregReasons = 1 << 19
+	ReregsToLottery       ReregReasons = 1 << 20
+	ReregsFFDVersion      ReregReasons = 1 << 21 // изменение версии ФФД
+	ReregsOther           ReregReasons = 1 << 31 // иные причины
+)
+
+var reregReasonsNames = []enumName{
+	0:  {"FN replacement", "замена ФН"},
+	1:  {"OFD change", "замена ОФД"},
+	2:  {"user name change", "изменение наименования пользователя"},
+	3:  {"address change", "изменение адреса и (или) места расчетов"},
+	4:  {"to online mode", "перевод из автономного режима в режим передачи данных"},
+	5:  {"to offline mode", "перевод из режима передачи данных в автономный режим"},
+	6:  {"model version change", "изменение версии модели ККТ"},
+	7:  {"tax systems change", "изменение перечня систем налогообложения"},
+	8:  {"automat number change", "изменение номера автоматического устройства"},
+	9:  {"from automatic mode", "перевод из автоматического режима в неавтоматический"},
+	10: {"to automatic mode", "перевод из неавтоматического режима в автоматический"},
+	11: {"from BSO mode", "перевод из режима формирования БСО"},
+	12: {"to BSO mode", "перевод в режим формирования БСО"},
+	13: {"from internet mode", "перевод из режима расчетов в сети Интернет"},
+	14: {"to internet mode", "перевод в режим расчетов в сети Интернет"},
+	15: {"from paying agent mode", "перевод из режима платежного агента"},
+	16: {"to paying agent mode", "перевод в режим платежного агента"},
+	17: {"from gambling mode", "перевод из режима азартных игр"},
+	18: {"to gambling mode", "перевод в режим азартных игр"},
+	19: {"from lottery mode", "перевод из режима лотерей"},
+	20: {"to lottery mode", "перевод в режим лотерей"},
+	21: {"FFD version change", "изменение версии ФФД"},
+	31: {"other", "иные причины"},
+}
+
+func (x ReregReasons) Valid() bool { return maskValid(reregReasonsNames, uint64(x)) }
+func (x ReregReasons) String() string {
+	return maskString(reregReasonsNames, uint64(x), "ReregReasons", false)
+}
+func (x ReregReasons) StringRu() string {
+	return maskString(reregReasonsNames, uint64(x), "ReregReasons", true)
+}
+func ParseReregReasons(s string) (ReregReasons, error) {
+	n, err := maskParse(reregReasonsNames, s, "ReregReasons")
+	return ReregReasons(n), err
+}
+
+// Implemented by all enum and bit mask types above.
+type Enum interface {
+	Valid() bool
+	String() string
+	StringRu() string
+}
+
+// Tags with enumerated values. SetValue rejects enum of other type or invalid enum value and stores any number,
+// so documents from FN or OFD with values unknown here are decoded. Builders check values with checkEnum.
+var enumTags = map[Tag]struct {
+	new    func(uint64) Enum
+	single bool // bit mask with exactly one bit set
+}{
+	TagCalcSign:       {new: func(n uint64) Enum { return CalcSign(n) }},
+	TagTaxSystem:      {new: func(n uint64) Enum { return TaxSystem(n) }, single: true},
+	TagTaxSystems:     {new: func(n uint64) Enum { return TaxSystem(n) }},
+	TagVATRate:        {new: func(n uint64) Enum { return VATRate(n) }},
+	TagPaymentSubject: {new: func(n uint64) Enum { return PaymentSubject(n) }},
+	TagPaymentMethod:  {new: func(n uint64) Enum { return PaymentMethod(n) }},
+	TagAgentFlags:     {new: func(n uint64) Enum { return AgentFlags(n) }},
+	TagItemAgentFlags: {new: func(n uint64) Enum { return AgentFlags(n) }, single: true},
+	TagReregReason:    {new: func(n uint64) Enum { return ReregReason(n) }},
+	TagReregReasons:   {new: func(n uint64) Enum { return ReregReasons(n) }},
+}
+
+// Invalid value of enumerated tag, returned by SetValue of enum type, Receipt.Doc and Set, reported by Validate.
+type EnumError struct {
+	Tag   Tag
+	Type  string
+	Value uint64
+}
+
+func (e *EnumError) Error() string {
+	return fmt.Sprintf("tag=%d invalid %s value=%d", e.Tag, e.Type, e.Value)
+}
+
+// Returns *TypeError for enum value of other tag, *EnumError for invalid enum value,
+// nil for numbers and tags without enum.
+func checkEnumType(tag Tag, value interface{}) error {
+	e, ok := enumTags[tag]
+	if !ok {
+		return nil
+	}
+	if _, isEnum := value.(Enum); !isEnum {
+		return nil
+	}
+	if reflect.TypeOf(value) != reflect.TypeOf(e.new(0)) {
+		return &TypeError{Op: "SetValue", Tag: tag, Kind: DataKindUint, Value: value}
+	}
+	n, _ := toUint64(value)
+	return checkEnum(tag, n)
+}
+
+// Returns *EnumError for value unknown in enum of tag, nil for tags without enum.
+func checkEnum(tag Tag, n uint64) error {
+	e, ok := enumTags[tag]
+	if !ok {
+		return nil
+	}
+	if x := e.new(n); !x.Valid() || (e.single && n&(n-1) != 0) {
+		return &EnumError{Tag: tag, Type: reflect.TypeOf(x).Name(), Value: n}
+	}
+	return nil
+}
+
+// Checks stored enum value, see checkEnum.
+func (self *TLV) checkEnum() error {
+	if n, ok := toUint64(self.valueOrNil()); ok && self.Kind == DataKindUint {
+		return checkEnum(self.Tag, n)
+	}
+	return nil
+}
+
+// Typed getters return *TypeError if tag does not hold this enum type.
+
+func (self *TLV) CalcSign() CalcSign   { return mustValue(self.TryCalcSign()).(CalcSign) }
+func (self *TLV) TaxSystem() TaxSystem { return mustValue(self.TryTaxSystem()).(TaxSystem) }
+func (self *TLV) VATRate() VATRate     { return mustValue(self.TryVATRate()).(VATRate) }
+func (self *TLV) PaymentSubject() PaymentSubject {
+	return mustValue(self.TryPaymentSubject()).(PaymentSubject)
+}
+func (self *TLV) PaymentMethod() PaymentMethod {
+	return mustValue(self.TryPaymentMethod()).(PaymentMethod)
+}
+func (self *TLV) AgentFlags() AgentFlags     { return mustValue(self.TryAgentFlags()).(AgentFlags) }
+func (self *TLV) ReregReason() ReregReason   { return mustValue(self.TryReregReason()).(ReregReason) }
+func (self *TLV) ReregReasons() ReregReasons { return mustValue(self.TryReregReasons()).(ReregReasons) }
+
+func (self *TLV) TryCalcSign() (CalcSign, error) {
+	n, err := self.tryEnum("CalcSign", CalcSign(0))
+	return CalcSign(n), err
+}
+
+func (self *TLV) TryTaxSystem() (TaxSystem, error) {
+	n, err := self.tryEnum("TaxSystem", TaxSystem(0))
+	return TaxSystem(n), err
+}
+
+func (self *TLV) TryVATRate() (VATRate, error) {
+	n, err := self.tryEnum("VATRate", VATRate(0))
+	return VATRate(n), err
+}
+
+func (self *TLV) TryPaymentSubject() (PaymentSubject, error) {
+	n, err := self.tryEnum("PaymentSubject", PaymentSubject(0))
+	return PaymentSubject(n), err
+}
+
+func (self *TLV) TryPaymentMethod() (PaymentMethod, error) {
+	n, err := self.tryEnum("PaymentMethod", PaymentMethod(0))
+	return PaymentMethod(n), err
+}
+
+func (self *TLV) TryAgentFlags() (AgentFlags, error) {
+	n, err := self.tryEnum("AgentFlags", AgentFlags(0))
+	return AgentFlags(n), err
+}
+
+func (self *TLV) TryReregReason() (ReregReason, error) {
+	n, err := self.tryEnum("ReregReason", ReregReason(0))
+	return ReregReason(n), err
+}
+
+func (self *TLV) TryReregReasons() (ReregReasons, error) {
+	n, err := self.tryEnum("ReregReasons", ReregReasons(0))
+	return ReregReasons(n), err
+}
+
+func (self *TLV) tryEnum(op string, want Enum) (uint32, error) {
+	n, err := self.TryUint32()
+	if err != nil {
+		return 0, err
+	}
+	if e, ok := enumTags[self.Tag]; !ok || reflect.TypeOf(e.new(0)) != reflect.TypeOf(want) {
+		return 0, self.typeError(op)
+	}
+	return n, nil
+}
+
+type enumName struct{ en, ru string }
+
+func enumValid(names []enumName, n uint64) bool {
+	return n < uint64(len(names)) && names[n].en != ""
+}
+
+func enumString(names []enumName, n uint64, typ string, ru bool) string {
+	if !enumValid(names, n) {
+		return fmt.Sprintf("%s(%d)", typ, n)
+	}
+	if ru {
+		return names[n].ru
+	}
+	return names[n].en
+}
+
+func enumParse(names []enumName, s, typ string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if !enumValid(names, n) {
+			return 0, fmt.Errorf("Parse%s s=%q invalid value", typ, s)
+		}
+		return n, nil
+	}
+	for i, name := range names {
+		if name.en != "" && (strings.EqualFold(s, name.en) || strings.EqualFold(s, name.ru)) {
+			return uint64(i), nil
+		}
+	}
+	return 0, fmt.Errorf("Parse%s s=%q unknown name", typ, s)
+}
+
+// Bit mask names are indexed by bit number.
+func maskValid(names []enumName, n uint64) bool {
+	if n == 0 {
+		return false
+	}
+	for bit := uint(0); n != 0; bit, n = bit+1, n>>1 {
+		if n&1 != 0 && !enumValid(names, uint64(bit)) {
+			return false
+		}
+	}
+	return true
+}
+
+// "common|patent"
+func maskString(names []enumName, n uint64, typ string, ru bool) string {
+	if !maskValid(names, n) {
+		return fmt.Sprintf("%s(%d)", typ, n)
+	}
+	parts := make([]string, 0, 2)
+	for bit := range names {
+		if n&(1<<uint(bit)) != 0 {
+			parts = append(parts, enumString(names, uint64(bit), typ, ru))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// Accepts number or names separated by '|' or ','.
+func maskParse(names []enumName, s, typ string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if !maskValid(names, n) {
+			return 0, fmt.Errorf("Parse%s s=%q invalid value", typ, s)
+		}
+		return n, nil
+	}
+	n := uint64(0)
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '|' || r == ',' }) {
+		bit, err := enumParse(names, part, typ)
+		if err != nil {
+			return 0, err
+		}
+		n |= 1 << bit
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("Parse%s s=%q empty", typ, s)
+	}
+	return n, nil
+}
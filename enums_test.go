@@ -0,0 +1,128 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "income return", CalcIncomeReturn.String())
+	assert.Equal(t, "возврат прихода", CalcIncomeReturn.StringRu())
+	assert.Equal(t, "VATRate(9)", VATRate(9).String())
+	assert.Equal(t, "без НДС", VATNone.StringRu())
+	assert.Equal(t, "common|patent", (TaxCommon | TaxPatent).String())
+	assert.Equal(t, "ОСН|ПСН", (TaxCommon | TaxPatent).StringRu())
+	assert.Equal(t, "TaxSystem(0)", TaxSystem(0).String())
+	assert.Equal(t, "AgentFlags(128)", AgentFlags(128).String())
+	assert.Equal(t, "FN replacement|other", (ReregsFN | ReregsOther).String())
+}
+
+func TestEnumParse(t *testing.T) {
+	t.Parallel()
+
+	v, err := ParseVATRate("20/120")
+	assert.NoError(t, err)
+	assert.Equal(t, VAT20120, v)
+	v, err = ParseVATRate("без ндс")
+	assert.NoError(t, err)
+	assert.Equal(t, VATNone, v)
+	v, err = ParseVATRate(" 2 ")
+	assert.NoError(t, err)
+	assert.Equal(t, VAT10, v)
+	_, err = ParseVATRate("9")
+	assert.Error(t, err)
+	_, err = ParseVATRate("13%")
+	assert.Error(t, err)
+
+	ts, err := ParseTaxSystem("УСН доход|patent")
+	assert.NoError(t, err)
+	assert.Equal(t, TaxSimplifiedIncome|TaxPatent, ts)
+	ts, err = ParseTaxSystem("3")
+	assert.NoError(t, err)
+	assert.Equal(t, TaxCommon|TaxSimplifiedIncome, ts)
+	_, err = ParseTaxSystem("64")
+	assert.Error(t, err)
+	_, err = ParseTaxSystem("")
+	assert.Error(t, err)
+
+	for _, x := range []CalcSign{CalcIncome, CalcIncomeReturn, CalcExpense, CalcExpenseReturn} {
+		parsed, err := ParseCalcSign(x.String())
+		assert.NoError(t, err)
+		assert.Equal(t, x, parsed)
+		parsed, err = ParseCalcSign(x.StringRu())
+		assert.NoError(t, err)
+		assert.Equal(t, x, parsed)
+	}
+}
+
+func TestEnumSetValue(t *testing.T) {
+	t.Parallel()
+
+	tlv := NewTLV(1199)
+	require.NoError(t, tlv.TrySetValue(VAT10))
+	assert.Equal(t, VAT10, tlv.VATRate())
+	_, err := tlv.TryCalcSign()
+	assert.IsType(t, &TypeError{}, err)
+
+	// values unknown here are stored as is, so documents from FN are decoded
+	require.NoError(t, tlv.TrySetValue(9))
+	assert.Equal(t, VATRate(9), tlv.VATRate())
+	err = tlv.checkEnum()
+	if assert.Error(t, err) {
+		assert.IsType(t, &EnumError{}, err)
+		assert.Equal(t, "tag=1199 invalid VATRate value=9", err.Error())
+	}
+	// enum of other tag
+	err = tlv.TrySetValue(CalcIncome)
+	assert.IsType(t, &TypeError{}, err)
+	assert.Panics(t, func() { tlv.SetValue(CalcIncome) })
+	// invalid value of enum type
+	err = tlv.TrySetValue(VATRate(9))
+	assert.IsType(t, &EnumError{}, err)
+	err = NewTLV(1054).TrySetValue(CalcSign(9))
+	assert.EqualError(t, err, "tag=1054 invalid CalcSign value=9")
+	assert.IsType(t, &EnumError{}, NewTLV(1055).TrySetValue(TaxCommon|TaxPatent))
+
+	// single bit
+	assert.NoError(t, checkEnum(1055, uint64(TaxPatent)))
+	assert.Error(t, checkEnum(1055, uint64(TaxCommon|TaxPatent)))
+	assert.NoError(t, checkEnum(1062, uint64(TaxCommon|TaxPatent)))
+	assert.NoError(t, checkEnum(1205, uint64(ReregsFN|ReregsOther)))
+	assert.Error(t, checkEnum(1205, 1<<25))
+	assert.Error(t, checkEnum(1057, 0))
+	assert.NoError(t, checkEnum(1001, 0))
+	tlv = NewTLV(1062)
+	assert.NoError(t, tlv.TrySetValue(TaxCommon|TaxPatent))
+	assert.Equal(t, TaxCommon|TaxPatent, tlv.TaxSystem())
+
+	// builders reject unknown values
+	d := NewDoc(0, FDCheck)
+	_, err = d.Set(1054, 9)
+	assert.IsType(t, &EnumError{}, err)
+	_, err = (&Receipt{Operation: CalcIncome, Items: []Item{{Name: "x", VATRate: 9}}}).Doc()
+	assert.EqualError(t, err, "Receipt.Doc tag=1199: tag=1199 invalid VATRate value=9")
+}
+
+func TestEnumDecode(t *testing.T) {
+	t.Parallel()
+
+	// FFD 1.2 payment subject and empty agent flags from FN
+	d := newTestCheck()
+	d.Get("1059/1212").SetValue(33)
+	d.AppendNew(1057, 0)
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+	parsed := &Doc{}
+	require.NoError(t, parsed.UnmarshalBinary(b))
+	assert.Equal(t, d.String(), parsed.String())
+	assert.Equal(t, PaymentSubject(33), parsed.Get("1059/1212").PaymentSubject())
+
+	assert.Equal(t, Violations{
+		{Kind: ViolationInvalid, Tag: 1212, Parent: 1059, Path: "1059/1212"},
+		{Kind: ViolationInvalid, Tag: 1057, Path: "1057"},
+	}, parsed.Validate(FFD105))
+}
@@ -0,0 +1,33 @@
+package ru_nalog
+
+import "fmt"
+
+// Tag is not found in TagRegistry.
+type UnknownTagError struct {
+	Tag Tag
+}
+
+func (e *UnknownTagError) Error() string { return fmt.Sprintf("unknown tag=%d", e.Tag) }
+
+// Child is appended to TLV which is not STLV.
+type NotSTLVError struct {
+	Op   string
+	Tag  Tag
+	Kind DataKind
+}
+
+func (e *NotSTLVError) Error() string {
+	return fmt.Sprintf("%s #%d kind=%s is not STLV", e.Op, e.Tag, e.Kind.String())
+}
+
+// Value type does not match TLV kind, returned by TrySetValue and Try* accessors.
+type TypeError struct {
+	Op    string
+	Tag   Tag
+	Kind  DataKind
+	Value interface{}
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("%s #%d kind=%s unexpected value=%#v", e.Op, e.Tag, e.Kind.String(), e.Value)
+}
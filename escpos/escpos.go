@@ -0,0 +1,130 @@
+// Package escpos renders printable form of fiscal documents to ESC/POS commands
+// for non-fiscal thermal printers, text is encoded in CP866.
+package escpos
+
+import (
+	"bytes"
+	"strings"
+
+	ru_nalog "github.com/temoto/ru-nalog-go"
+	"github.com/temoto/ru-nalog-go/cp866"
+)
+
+// ESC t argument selecting CP866 on Epson compatible printers, some clones use 7 or 46.
+const CodePageCP866 = 17
+
+const (
+	esc = 0x1b
+	gs  = 0x1d
+	lf  = 0x0a
+)
+
+type Encoder struct {
+	Width    int  // characters per line, 0 means ru_nalog.DefaultPrintWidth
+	CodePage byte // see CodePageCP866
+	QRModule byte // QR module size in dots 1-16, 0 means 4
+	NoCut    bool // skip paper cut at the end
+}
+
+func NewEncoder() *Encoder {
+	return &Encoder{Width: ru_nalog.DefaultPrintWidth, CodePage: CodePageCP866}
+}
+
+// Printable form of d, see Encode.
+func (e *Encoder) EncodeDoc(d *ru_nalog.Doc) []byte { return e.Encode(d.PrintLines()) }
+
+// Initializes printer, prints lines with bold captions and titles,
+// QR payload as native QR code, feeds and cuts paper.
+func (e *Encoder) Encode(lines ru_nalog.PrintLines) []byte {
+	b := &bytes.Buffer{}
+	b.Write([]byte{esc, '@', esc, 't', e.CodePage})
+	for _, l := range lines {
+		switch l.Kind {
+		case ru_nalog.PrintTitle:
+			align(b, 1)
+			bold(b, true)
+			for _, s := range l.Text(e.Width) {
+				text(b, strings.TrimLeft(s, " ")) // centered by printer
+			}
+			bold(b, false)
+			align(b, 0)
+		case ru_nalog.PrintQR:
+			align(b, 1)
+			e.qr(b, l.Value)
+			align(b, 0)
+		default:
+			e.line(b, l)
+		}
+	}
+	if !e.NoCut {
+		// feed past cutter, partial cut
+		b.Write([]byte{esc, 'd', 4, gs, 'V', 1})
+	}
+	return b.Bytes()
+}
+
+// Captions are bold, value is on the same line when it fits.
+func (e *Encoder) line(b *bytes.Buffer, l ru_nalog.PrintLine) {
+	ss := l.Text(e.Width)
+	captionLines := 0
+	if l.Kind == ru_nalog.PrintText && l.Caption != "" {
+		captionLines = len(ru_nalog.PrintLine{Kind: l.Kind, Caption: l.Caption}.Text(e.Width))
+	}
+	switch {
+	case l.Bold:
+		bold(b, true)
+		for _, s := range ss {
+			text(b, s)
+		}
+		bold(b, false)
+	case captionLines == 1 && len(ss) == 1:
+		n := len(l.Caption)
+		bold(b, true)
+		b.Write(cp866.Encode(ss[0][:n]))
+		bold(b, false)
+		text(b, ss[0][n:])
+	default:
+		for i, s := range ss {
+			if i == 0 && captionLines != 0 {
+				bold(b, true)
+			}
+			text(b, s)
+			if i == captionLines-1 {
+				bold(b, false)
+			}
+		}
+	}
+}
+
+// GS ( k: model 2, module size, error correction M, store and print.
+func (e *Encoder) qr(b *bytes.Buffer, payload string) {
+	module := e.QRModule
+	if module == 0 {
+		module = 4
+	}
+	data := []byte(payload)
+	n := len(data) + 3
+	b.Write([]byte{gs, '(', 'k', 4, 0, '1', 'A', '2', 0})
+	b.Write([]byte{gs, '(', 'k', 3, 0, '1', 'C', module})
+	b.Write([]byte{gs, '(', 'k', 3, 0, '1', 'E', '1'})
+	b.Write([]byte{gs, '(', 'k', byte(n), byte(n >> 8), '1', 'P', '0'})
+	b.Write(data)
+	b.Write([]byte{gs, '(', 'k', 3, 0, '1', 'Q', '0'})
+	b.WriteByte(lf)
+}
+
+func text(b *bytes.Buffer, s string) {
+	b.Write(cp866.Encode(s))
+	b.WriteByte(lf)
+}
+
+func bold(b *bytes.Buffer, on bool) {
+	n := byte(0)
+	if on {
+		n = 1
+	}
+	b.Write([]byte{esc, 'E', n})
+}
+
+// 0 left, 1 center
+func align(b *bytes.Buffer, n byte) { b.Write([]byte{esc, 'a', n}) }
@@ -0,0 +1,51 @@
+package escpos
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	ru_nalog "github.com/temoto/ru-nalog-go"
+	"github.com/temoto/ru-nalog-go/cp866"
+)
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	e := NewEncoder()
+	e.Width = 10
+	e.NoCut = true
+	b := e.Encode(ru_nalog.PrintLines{
+		{Kind: ru_nalog.PrintTitle, Value: "ЧЕК"},
+		{Kind: ru_nalog.PrintText, Caption: "ИНН", Value: "12"},
+		{Kind: ru_nalog.PrintText, Caption: "СНО", Value: "УСН доход"},
+		{Kind: ru_nalog.PrintText, Value: "чай"},
+		{Kind: ru_nalog.PrintSeparator},
+		{Kind: ru_nalog.PrintText, Caption: "ИТОГ", Value: "2,00", Bold: true},
+	})
+	expect := bytes.Join([][]byte{
+		{esc, '@', esc, 't', CodePageCP866},
+		{esc, 'a', 1, esc, 'E', 1}, cp866.Encode("ЧЕК\n"), {esc, 'E', 0, esc, 'a', 0},
+		{esc, 'E', 1}, cp866.Encode("ИНН"), {esc, 'E', 0}, cp866.Encode("     12\n"),
+		{esc, 'E', 1}, cp866.Encode("СНО\n"), {esc, 'E', 0}, cp866.Encode(" УСН доход\n"),
+		cp866.Encode("чай\n"),
+		[]byte("----------\n"),
+		{esc, 'E', 1}, cp866.Encode("ИТОГ  2,00\n"), {esc, 'E', 0},
+	}, nil)
+	assert.Equal(t, expect, b)
+}
+
+func TestEncodeDoc(t *testing.T) {
+	t.Parallel()
+
+	d := ru_nalog.NewDoc(1, ru_nalog.FDCheck)
+	d.AppendNew(1054, ru_nalog.CalcIncome)
+	const payload = "t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1"
+	d.AppendNew(1196, payload)
+	b := NewEncoder().EncodeDoc(d)
+	assert.True(t, bytes.HasSuffix(b, []byte{esc, 'd', 4, gs, 'V', 1}))
+	store := append([]byte{gs, '(', 'k', byte(len(payload) + 3), 0, '1', 'P', '0'}, payload...)
+	assert.True(t, bytes.Contains(b, store))
+	assert.True(t, bytes.Contains(b, []byte{gs, '(', 'k', 3, 0, '1', 'Q', '0'}))
+	assert.True(t, bytes.Contains(b, cp866.Encode("ПРИХОД")))
+}
@@ -0,0 +1,335 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Struct mapping by field tags:
+//
+//	type Item struct {
+//		Name  string  `fdn:"1030"`
+//		Price Money   `fdn:"1079"`
+//		Qty   Decimal `fdn:"1023"`
+//		Code  []byte  `fdn:"1162,omitempty"`
+//	}
+//	type Check struct {
+//		Type  DocType // document type, no tag
+//		Sign  CalcSign `fdn:"1054"`
+//		Items []Item   `fdn:"1059"` // STLV
+//	}
+//
+// Struct fields (except time.Time and Decimal) with tag are STLV, slices are repeated tags.
+// Untagged struct fields are inlined, untagged DocType field is Doc.Type, other untagged fields are ignored.
+// Nil pointers and, with omitempty, zero values are not written. Tag 1040 is also Doc.Number.
+
+var (
+	typeTime    = reflect.TypeOf(time.Time{})
+	typeDecimal = reflect.TypeOf(Decimal{})
+	typeDocType = reflect.TypeOf(DocType(0))
+)
+
+// Builds document from struct or pointer to struct, using DefaultTags.
+// Values of enum type fields are checked as in Set, plain numbers are stored as is.
+func Marshal(v interface{}) (*Doc, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("fdn.Marshal expected struct, got %T", v)
+	}
+	d := NewDoc(0, 0)
+	if err := marshalStruct(d, &d.Props, rv); err != nil {
+		return nil, err
+	}
+	if t := findChild(&d.Props, TagDocNumber); t != nil {
+		d.Number, _ = t.TryUint32()
+	}
+	return d, nil
+}
+
+// Fills struct pointed by v from document. Tags absent in document leave fields unchanged.
+func Unmarshal(d *Doc, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fdn.Unmarshal expected non-nil pointer to struct, got %T", v)
+	}
+	if d == nil {
+		return fmt.Errorf("fdn.Unmarshal doc=nil")
+	}
+	children := d.Props.Children()
+	if d.Number != 0 && findChild(&d.Props, TagDocNumber) == nil {
+		children = append(children[:len(children):len(children)], *NewTLV(TagDocNumber))
+		children[len(children)-1].SetValue(d.Number)
+	}
+	return unmarshalStruct(d, children, rv.Elem())
+}
+
+type fdnField struct {
+	tag       Tag
+	omitempty bool
+}
+
+// ok=false for untagged and "-" fields.
+func parseFdnTag(f reflect.StructField) (fdnField, bool, error) {
+	s, ok := f.Tag.Lookup("fdn")
+	if !ok || s == "-" {
+		return fdnField{}, false, nil
+	}
+	parts := strings.Split(s, ",")
+	n, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return fdnField{}, false, fmt.Errorf("fdn field=%s invalid tag=%q", f.Name, s)
+	}
+	ff := fdnField{tag: Tag(n)}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			ff.omitempty = true
+		}
+	}
+	return ff, true, nil
+}
+
+func isSTLVType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != typeTime && t != typeDecimal
+}
+
+func isRepeatedType(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+func marshalStruct(d *Doc, parent *TLV, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !(f.Anonymous && isSTLVType(f.Type)) {
+			continue // unexported
+		}
+		fv := rv.Field(i)
+		ff, ok, err := parseFdnTag(f)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			switch {
+			case f.Type == typeDocType:
+				d.Type = DocType(fv.Uint())
+			case isSTLVType(f.Type):
+				err = marshalStruct(d, parent, fv)
+			case f.Type.Kind() == reflect.Ptr && isSTLVType(f.Type.Elem()) && !fv.IsNil():
+				err = marshalStruct(d, parent, fv.Elem())
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if ff.omitempty && isZeroValue(fv) {
+			continue
+		}
+		if isRepeatedType(f.Type) {
+			for j := 0; j < fv.Len(); j++ {
+				if err := marshalValue(d, parent, ff.tag, fv.Index(j), f.Name); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := marshalValue(d, parent, ff.tag, fv, f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalValue(d *Doc, parent *TLV, tag Tag, fv reflect.Value, name string) error {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	if isSTLVType(fv.Type()) {
+		child, err := parent.appendNew(d.Tags, tag, nil)
+		if err != nil {
+			return fmt.Errorf("fdn.Marshal field=%s tag=%d: %v", name, tag, err)
+		}
+		return marshalStruct(d, child, fv)
+	}
+	var value interface{}
+	switch fv.Kind() {
+	case reflect.Bool:
+		value = fv.Bool()
+	case reflect.String:
+		value = fv.String()
+	case reflect.Float32, reflect.Float64:
+		value = fv.Float()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if fv.Int() < 0 {
+			return fmt.Errorf("fdn.Marshal field=%s tag=%d negative value=%d", name, tag, fv.Int())
+		}
+		value = uint64(fv.Int())
+	case reflect.Slice:
+		value = fv.Bytes()
+	default:
+		value = fv.Interface() // enums keep type for SetValue check
+	}
+	t, err := parent.appendNew(d.Tags, tag, value)
+	if _, isEnum := value.(Enum); isEnum && err == nil {
+		// numeric fields keep values reported by device, like Doc decoders
+		err = t.checkEnum()
+	}
+	if err != nil {
+		return fmt.Errorf("fdn.Marshal field=%s tag=%d: %v", name, tag, err)
+	}
+	return nil
+}
+
+func isZeroValue(fv reflect.Value) bool {
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return fv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return fv.IsNil()
+	}
+	return reflect.DeepEqual(fv.Interface(), reflect.Zero(fv.Type()).Interface())
+}
+
+func unmarshalStruct(d *Doc, children []TLV, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" && !(f.Anonymous && isSTLVType(f.Type)) {
+			continue
+		}
+		fv := rv.Field(i)
+		ff, ok, err := parseFdnTag(f)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			switch {
+			case f.Type == typeDocType:
+				fv.SetUint(uint64(d.Type))
+			case isSTLVType(f.Type):
+				err = unmarshalStruct(d, children, fv)
+			case f.Type.Kind() == reflect.Ptr && isSTLVType(f.Type.Elem()):
+				if fv.IsNil() {
+					fv.Set(reflect.New(f.Type.Elem()))
+				}
+				err = unmarshalStruct(d, children, fv.Elem())
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		matches := make([]*TLV, 0, 1)
+		for j := range children {
+			if children[j].Tag == ff.tag {
+				matches = append(matches, &children[j])
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		if isRepeatedType(f.Type) {
+			slice := reflect.MakeSlice(f.Type, len(matches), len(matches))
+			for j, t := range matches {
+				if err := unmarshalValue(d, t, slice.Index(j), f.Name); err != nil {
+					return err
+				}
+			}
+			fv.Set(slice)
+			continue
+		}
+		if err := unmarshalValue(d, matches[0], fv, f.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(d *Doc, t *TLV, fv reflect.Value, name string) error {
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			fv.Set(reflect.New(fv.Type().Elem()))
+		}
+		fv = fv.Elem()
+	}
+	wrap := func(err error) error { return fmt.Errorf("fdn.Unmarshal field=%s tag=%d: %v", name, t.Tag, err) }
+	if isSTLVType(fv.Type()) {
+		if t.Kind != DataKindSTLV {
+			return wrap(t.typeError("STLV"))
+		}
+		return unmarshalStruct(d, t.Children(), fv)
+	}
+	switch fv.Type() {
+	case typeTime:
+		x, err := t.TryTime()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.Set(reflect.ValueOf(x))
+		return nil
+	case typeDecimal:
+		x, err := t.TryDecimal()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.Set(reflect.ValueOf(x))
+		return nil
+	}
+	switch fv.Kind() {
+	case reflect.Bool:
+		x, err := t.TryBool()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetBool(x)
+	case reflect.String:
+		x, err := t.TryString()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetString(x)
+	case reflect.Slice:
+		x, err := t.TryBytes()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetBytes(append([]byte(nil), x...))
+	case reflect.Float32, reflect.Float64:
+		x, err := t.TryFloat64()
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetFloat(x)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		x, err := t.TryUint64()
+		if err == nil && fv.OverflowUint(x) {
+			err = fmt.Errorf("value=%d overflows %s", x, fv.Type())
+		}
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetUint(x)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err := t.TryUint64()
+		if err == nil && (x > math.MaxInt64 || fv.OverflowInt(int64(x))) {
+			err = fmt.Errorf("value=%d overflows %s", x, fv.Type())
+		}
+		if err != nil {
+			return wrap(err)
+		}
+		fv.SetInt(int64(x))
+	default:
+		return wrap(fmt.Errorf("unsupported field type %s", fv.Type()))
+	}
+	return nil
+}
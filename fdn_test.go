@@ -0,0 +1,117 @@
+package ru_nalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testFdnItem struct {
+	Name    string         `fdn:"1030"`
+	Price   Money          `fdn:"1079"`
+	Qty     Decimal        `fdn:"1023"`
+	VAT     VATRate        `fdn:"1199"`
+	Subject PaymentSubject `fdn:"1212,omitempty"`
+	Code    []byte         `fdn:"1162,omitempty"`
+}
+
+type testFdnCashier struct {
+	Name string `fdn:"1021"`
+	INN  string `fdn:"1203,omitempty"`
+}
+
+type testFdnCheck struct {
+	Type     DocType
+	Number   uint32        `fdn:"1040"`
+	Time     time.Time     `fdn:"1012"`
+	Sign     CalcSign      `fdn:"1054"`
+	Auto     bool          `fdn:"1001"`
+	Contacts []string      `fdn:"1008"`
+	Items    []testFdnItem `fdn:"1059"`
+	Extra    *struct {
+		Name  string `fdn:"1085"`
+		Value string `fdn:"1086"`
+	} `fdn:"1084"`
+	Total   int `fdn:"1020"`
+	Ignored string
+	Skipped string `fdn:"-"`
+	testFdnCashier
+}
+
+func TestFdnMarshal(t *testing.T) {
+	t.Parallel()
+
+	v1 := testFdnCheck{
+		Type:     FDCheck,
+		Number:   8493,
+		Time:     time.Unix(1579922299, 0).UTC(),
+		Sign:     CalcIncome,
+		Contacts: []string{"e@ma.il"},
+		Items: []testFdnItem{
+			{Name: "a", Price: 1999, Qty: NewDecimal(1500, 3), VAT: VAT20, Subject: SubjectCommodity},
+			{Name: "b", Price: 1001, Qty: NewDecimal(1, 0), VAT: VATNone, Code: []byte{1, 2}},
+		},
+		Total:          4000,
+		Ignored:        "x",
+		Skipped:        "y",
+		testFdnCashier: testFdnCashier{Name: "Иванов"},
+	}
+	d, err := Marshal(&v1)
+	require.NoError(t, err)
+	assert.Equal(t, FDCheck, d.Type)
+	assert.Equal(t, uint32(8493), d.Number)
+	assert.Equal(t, "Doc(#8493 Type=3 Props=[(#1040 212d) (#1012 2020-01-25T03:18:19Z) (#1054 1) (#1001 false) (#1008 e@ma.il) "+
+		"(#1059 [(#1030 a) (#1079 1999) (#1023 1.500) (#1199 1) (#1212 1)]) "+
+		"(#1059 [(#1030 b) (#1079 1001) (#1023 1) (#1199 6) (#1162 0102)]) "+
+		"(#1020 4000) (#1021 Иванов)])", d.String())
+
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+	parsed := &Doc{}
+	require.NoError(t, parsed.UnmarshalBinary(b))
+	v2 := testFdnCheck{}
+	require.NoError(t, Unmarshal(parsed, &v2))
+	v1.Ignored, v1.Skipped = "", ""
+	assert.True(t, v1.Time.Equal(v2.Time))
+	v2.Time = v1.Time
+	assert.Equal(t, v1, v2)
+
+	v1.Extra = &struct {
+		Name  string `fdn:"1085"`
+		Value string `fdn:"1086"`
+	}{Name: "цвет", Value: "синий"}
+	d, err = Marshal(v1)
+	require.NoError(t, err)
+	v3 := testFdnCheck{}
+	require.NoError(t, Unmarshal(d, &v3))
+	require.NotNil(t, v3.Extra)
+	assert.Equal(t, *v1.Extra, *v3.Extra)
+
+	_, err = Marshal(testFdnCheck{Time: v1.Time, Sign: 9})
+	assert.EqualError(t, err, "fdn.Marshal field=Sign tag=1054: tag=1054 invalid CalcSign value=9")
+	_, err = Marshal(struct {
+		Sign CalcSign `fdn:"1054"`
+	}{9})
+	assert.Error(t, err)
+	_, err = Marshal(testFdnCheck{Total: -1})
+	assert.Error(t, err)
+	_, err = Marshal(1)
+	assert.Error(t, err)
+	assert.Error(t, Unmarshal(d, v3))
+	assert.Error(t, Unmarshal(nil, &v3))
+
+	// Number is taken from Doc when 1040 is absent
+	v4 := struct {
+		Number uint8 `fdn:"1040"`
+	}{}
+	assert.Error(t, Unmarshal(NewDoc(8493, FDCheck), &v4))
+	v5 := struct {
+		Number uint32 `fdn:"1040"`
+		Name   bool   `fdn:"1021"`
+	}{}
+	require.NoError(t, Unmarshal(NewDoc(8493, FDCheck), &v5))
+	assert.Equal(t, uint32(8493), v5.Number)
+	assert.Error(t, Unmarshal(d, &v5))
+}
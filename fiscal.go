@@ -41,6 +41,7 @@ type Doc struct {
 	Number uint32 `fdn:"1040"`
 	Type   DocType
 	Props  TLV
+	Tags   *TagRegistry // nil means DefaultTags
 }
 
 func NewDoc(number uint32, dtype DocType) *Doc {
@@ -55,19 +56,30 @@ func NewDoc(number uint32, dtype DocType) *Doc {
 }
 
 func (d *Doc) FindByTag(tag Tag) *TLV {
-	for _, child := range d.Props.Children() {
-		if t := child.FindByTag(tag); t != nil {
+	cs := d.Props.Children()
+	for i := range cs {
+		if t := cs[i].FindByTag(tag); t != nil {
 			return t
 		}
 	}
 	return nil
 }
 
+// Returns nil for unknown tag. Panics on unhandled value type, see AppendNewE.
 func (d *Doc) AppendNew(tag Tag, value interface{}) *TLV {
+	t, err := d.AppendNewE(tag, value)
+	if _, ok := err.(*TypeError); ok {
+		panic(err)
+	}
+	return t
+}
+
+// See TLV.AppendNewE.
+func (d *Doc) AppendNewE(tag Tag, value interface{}) (*TLV, error) {
 	if d == nil {
-		return nil
+		return nil, fmt.Errorf("Doc(nil).AppendNewE #%d", tag)
 	}
-	return d.Props.AppendNew(tag, value)
+	return d.Props.appendNew(d.Tags, tag, value)
 }
 
 func (d *Doc) String() string {
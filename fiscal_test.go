@@ -21,3 +21,39 @@ func TestDocAppend(t *testing.T) {
 	assert.NoError(t, row.AppendNew(1214, 1).Err())
 	assert.Equal(t, "Doc(#0 Type=3 Props=[(#1054 1) (#1055 2) (#1008 e@ma.il) (#1036 102030) (#1059 [(#1023 1) (#1030 item) (#1079 7) (#1199 6) (#1212 1) (#1214 1)])])", d.String())
 }
+
+func TestDocAppendNewE(t *testing.T) {
+	t.Parallel()
+
+	d := NewDoc(0, FDCheck)
+	tlv, err := d.AppendNewE(1, 1)
+	assert.Nil(t, tlv)
+	if assert.Error(t, err) {
+		assert.IsType(t, &UnknownTagError{}, err)
+	}
+	assert.Nil(t, d.AppendNew(1, 1))
+	assert.Len(t, d.Props.Children(), 0)
+
+	tlv, err = d.AppendNewE(1001, 7)
+	if assert.Error(t, err) {
+		assert.IsType(t, &TypeError{}, err)
+	}
+	assert.Equal(t, err, tlv.Err())
+	assert.Len(t, d.Props.Children(), 1)
+	assert.Panics(t, func() { d.AppendNew(1001, 7) })
+
+	_, err = d.AppendNewE(1018, "7725225244123")
+	assert.IsType(t, &LengthError{}, err)
+	assert.NotPanics(t, func() { d.AppendNew(1018, "7725225244123") })
+
+	item, err := d.AppendNewE(1059, nil)
+	assert.NoError(t, err)
+	_, err = item.AppendNewE(1023, 1)
+	assert.NoError(t, err)
+	leaf := item.Children()[0]
+	_, err = leaf.AppendNewE(1030, "item")
+	assert.IsType(t, &NotSTLVError{}, err)
+	assert.NotPanics(t, func() { assert.Nil(t, leaf.AppendNew(1030, "item")) })
+	assert.NotPanics(t, func() { assert.Nil(t, (&Doc{}).AppendNew(1030, "item")) })
+	assert.Panics(t, func() { item.AppendNew(1001, 7) })
+}
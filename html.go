@@ -0,0 +1,149 @@
+package ru_nalog
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+
+	"github.com/temoto/ru-nalog-go/qr"
+)
+
+// Data of electronic receipt template.
+type HTMLReceipt struct {
+	Doc   *Doc
+	Title string
+	Lines PrintLines
+	QR    template.URL // PNG data URL, empty without QR payload
+}
+
+// Electronic receipt page over Doc.PrintLines, see NewHTMLTemplate.
+const DefaultHTMLTemplate = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>{{block "style" .}}
+.receipt { font-family: monospace; max-width: 42em; margin: 1em auto; }
+.receipt .text { display: flex; justify-content: space-between; }
+.receipt .title { text-align: center; }
+.receipt .bold { font-weight: bold; }
+.receipt .value { text-align: right; white-space: pre-wrap; }
+.receipt .qr { display: block; margin: 1em auto; }
+.receipt .qr-text { text-align: center; word-break: break-all; font-size: smaller; }
+{{end}}</style>
+</head>
+<body>
+{{block "header" .}}{{end}}
+<div class="receipt">
+{{range .Lines}}{{if eq .Kind.String "separator"}}<hr>
+{{else if eq .Kind.String "qr"}}{{if $.QR}}<img class="qr" src="{{$.QR}}" alt="QR">
+{{end}}<div class="qr-text">{{.Value}}</div>
+{{else}}<div class="{{.Kind}}{{if .Bold}} bold{{end}}">{{if .Caption}}<span class="caption">{{.Caption}}</span>{{end}}{{if .Value}}<span class="value">{{.Value}}</span>{{end}}</div>
+{{end}}{{end}}</div>
+{{block "footer" .}}{{end}}
+</body>
+</html>
+`
+
+var defaultHTMLTemplate = NewHTMLTemplate()
+
+// Parsed DefaultHTMLTemplate. To brand receipts, Parse definitions of "style", "header" or "footer" on it.
+func NewHTMLTemplate() *template.Template {
+	return template.Must(template.New("receipt").Parse(DefaultHTMLTemplate))
+}
+
+// Attributes required in electronic check, BSO and their corrections.
+var (
+	htmlRequired = []Tag{
+		TagUserName, TagUserINN, TagPaymentAddress, TagDateTime, TagCycleNumber, TagCalcSign, TagTaxSystem,
+		TagTotalSum, TagKKTRegNumber, TagFNNumber, TagDocNumber, TagFiscalSign, TagFNSSite,
+		TagSenderEmail, TagCustomerContact,
+	}
+	htmlRequiredNumbered   = []Tag{TagCheckNumber}
+	htmlRequiredItem       = []Tag{TagItemName, TagQuantity, TagPrice, TagItemSum, TagVATRate, TagPaymentMethod}
+	htmlRequiredCorrection = []Tag{TagCorrectionType, TagCorrectionBasis}
+)
+
+// Renders electronic receipt for check, correction check, BSO or correction BSO.
+// nil tmpl means NewHTMLTemplate, it is executed with HTMLReceipt.
+// Returns Violations when attributes required in electronic receipt are missing:
+// cashier 1021 is not required with automat number 1036, at least one payment tag is.
+func (d *Doc) HTML(tmpl *template.Template) ([]byte, error) {
+	correction := false
+	switch d.Type {
+	case FDCheck, FDBSO:
+	case FDCorrectionCheck, FDCorrectionBSO:
+		correction = true
+	default:
+		return nil, fmt.Errorf("Doc.HTML unsupported document type=%d", d.Type)
+	}
+	if err := d.checkElectronic(correction); err != nil {
+		return nil, err
+	}
+	if tmpl == nil {
+		tmpl = defaultHTMLTemplate
+	}
+
+	data := HTMLReceipt{Doc: d, Title: docTitles[d.Type], Lines: d.PrintLines()}
+	if code, err := d.QRCode(qr.M); err == nil {
+		png, err := code.PNG(0)
+		if err != nil {
+			return nil, fmt.Errorf("Doc.HTML QR: %v", err)
+		}
+		data.QR = template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)) // #nosec G203
+	}
+	b := bytes.Buffer{}
+	if err := tmpl.Execute(&b, &data); err != nil {
+		return nil, fmt.Errorf("Doc.HTML: %v", err)
+	}
+	return b.Bytes(), nil
+}
+
+func (d *Doc) checkElectronic(correction bool) error {
+	var vs Violations
+	missing := func(parent *TLV, prefix string, tags []Tag) {
+		parentTag := parent.Tag
+		if prefix == "" {
+			parentTag = 0
+		}
+		for _, tag := range tags {
+			if t := findChild(parent, tag); t == nil || t.Err() != nil {
+				vs = append(vs, Violation{Kind: ViolationMissing, Tag: tag, Parent: parentTag, Path: fmt.Sprintf("%s%d", prefix, tag)})
+			}
+		}
+	}
+	missing(&d.Props, "", htmlRequired)
+	if correction {
+		missing(&d.Props, "", htmlRequiredCorrection)
+	} else {
+		missing(&d.Props, "", htmlRequiredNumbered)
+	}
+	if findChild(&d.Props, TagAutomatNumber) == nil {
+		missing(&d.Props, "", []Tag{TagCashier})
+	}
+	paid := false
+	for _, tag := range paymentTags {
+		paid = paid || findChild(&d.Props, tag) != nil
+	}
+	if !paid {
+		missing(&d.Props, "", paymentTags[:1])
+	}
+
+	props := d.Props.Children()
+	paths := childPaths("", props)
+	items := 0
+	for i := range props {
+		if props[i].Tag == TagItem {
+			items++
+			missing(&props[i], paths[i]+"/", htmlRequiredItem)
+		}
+	}
+	if items == 0 && !correction {
+		missing(&d.Props, "", []Tag{TagItem})
+	}
+	if len(vs) == 0 {
+		return nil
+	}
+	return vs
+}
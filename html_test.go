@@ -0,0 +1,49 @@
+package ru_nalog
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocHTML(t *testing.T) {
+	t.Parallel()
+
+	d := newTestPrintDoc(t)
+	d.AppendNew(1008, "e@ma.il")
+	d.FindByTag(1030).SetValue("<b>товар</b>")
+	b, err := d.HTML(nil)
+	require.NoError(t, err)
+	s := string(b)
+	assert.Contains(t, s, "<title>КАССОВЫЙ ЧЕК</title>")
+	assert.Contains(t, s, `<div class="text bold"><span class="caption">ИТОГ</span><span class="value">2,00</span></div>`)
+	assert.Contains(t, s, `<div class="text"><span class="value">&lt;b&gt;товар&lt;/b&gt;</span></div>`)
+	assert.Contains(t, s, `<img class="qr" src="data:image/png;base64,iVBORw0KGgo`)
+	assert.Contains(t, s, `<div class="qr-text">t=20200125T0618&amp;s=2.00&amp;fn=9999078900003063&amp;i=8493&amp;fp=1765583868&amp;n=1</div>`)
+	assert.Equal(t, 3, strings.Count(s, "<hr>"))
+
+	brand := template.Must(NewHTMLTemplate().Parse(`{{define "header"}}<h1>Армакс {{.Doc.Number}}</h1>{{end}}`))
+	b, err = d.HTML(brand)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "<h1>Армакс 8493</h1>")
+	b, err = d.HTML(nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "<h1>")
+
+	d.Remove(1008)
+	d.Remove(1031)
+	d.Get("1059").Remove(1199)
+	_, err = d.HTML(nil)
+	if assert.IsType(t, Violations{}, err) {
+		assert.Equal(t, "document form violations: missing 1008, missing 1031, missing 1059/1199", err.Error())
+		assert.Equal(t, Tag(1059), err.(Violations)[2].Parent)
+	}
+
+	_, err = NewDoc(1, FDCycleOpen).HTML(nil)
+	assert.EqualError(t, err, "Doc.HTML unsupported document type=2")
+	_, err = NewDoc(1, FDCorrectionCheck).HTML(nil)
+	assert.Contains(t, err.Error(), "missing 1173, missing 1174, missing 1021, missing 1031")
+}
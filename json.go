@@ -0,0 +1,188 @@
+package ru_nalog
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// JSON schema of TLV, independent of device protocols:
+// {"tag":1059,"kind":"STLV","children":[{"tag":1023,"kind":"FVLN","value":"1.500"}]}
+// Values by kind: Bool true/false, Uint and VLN number, FVLN decimal string,
+// Time RFC3339 string, String string without padding, Bytes hex string.
+type jsonTLV struct {
+	Tag       Tag             `json:"tag"`
+	Kind      string          `json:"kind"`
+	Value     json.RawMessage `json:"value,omitempty"`
+	Children  []jsonTLV       `json:"children,omitempty"`
+	Caption   string          `json:"caption,omitempty"`
+	Printable string          `json:"printable,omitempty"`
+}
+
+type jsonDoc struct {
+	Number uint32    `json:"number"`
+	Type   DocType   `json:"type"`
+	Props  []jsonTLV `json:"props"`
+}
+
+// Inverse of DataKind.String()
+func ParseDataKind(s string) (DataKind, error) {
+	for k := DataKindSTLV; k <= DataKindBytes; k++ {
+		if k.String() == s {
+			return k, nil
+		}
+	}
+	return DataKindInvalid, fmt.Errorf("ParseDataKind s=%q unknown", s)
+}
+
+func (self *TLV) MarshalJSON() ([]byte, error) {
+	j, err := self.toJSON()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+// Tag and kind are checked against TagRegistry of self, DefaultTags for zero TLV.
+func (self *TLV) UnmarshalJSON(b []byte) error {
+	j := jsonTLV{}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	t, err := fromJSON(self.tags, &j)
+	if err != nil {
+		return err
+	}
+	*self = *t
+	return nil
+}
+
+func (d *Doc) MarshalJSON() ([]byte, error) {
+	j := jsonDoc{Number: d.Number, Type: d.Type}
+	cs := d.Props.Children()
+	j.Props = make([]jsonTLV, len(cs))
+	for i := range cs {
+		var err error
+		if j.Props[i], err = cs[i].toJSON(); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(j)
+}
+
+// Props are checked against d.Tags.
+func (d *Doc) UnmarshalJSON(b []byte) error {
+	j := jsonDoc{}
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	nd := NewDoc(j.Number, j.Type)
+	nd.Tags = d.Tags
+	for i := range j.Props {
+		t, err := fromJSON(d.Tags, &j.Props[i])
+		if err != nil {
+			return err
+		}
+		nd.Props.Append(t)
+	}
+	*d = *nd
+	return nil
+}
+
+func (self *TLV) toJSON() (jsonTLV, error) {
+	j := jsonTLV{Tag: self.Tag, Kind: self.Kind.String(), Caption: self.Caption, Printable: self.Printable}
+	if err := self.Err(); err != nil {
+		return j, err
+	}
+	var v interface{}
+	switch self.Kind {
+	case DataKindSTLV:
+		cs := self.Children()
+		j.Children = make([]jsonTLV, len(cs))
+		for i := range cs {
+			var err error
+			if j.Children[i], err = cs[i].toJSON(); err != nil {
+				return j, err
+			}
+		}
+		return j, nil
+	case DataKindBool, DataKindUint, DataKindVLN:
+		v = self.value
+	case DataKindFVLN:
+		v = self.Decimal().String()
+	case DataKindTime:
+		v = self.Time().Format(time.RFC3339)
+	case DataKindString:
+		v = self.String()
+	case DataKindBytes:
+		v = hex.EncodeToString(self.Bytes())
+	default:
+		return j, fmt.Errorf("TLV.MarshalJSON tag=%d unhandled kind=%s", self.Tag, self.Kind.String())
+	}
+	b, err := json.Marshal(v)
+	j.Value = b
+	return j, err
+}
+
+func fromJSON(tags *TagRegistry, j *jsonTLV) (*TLV, error) {
+	t := tags.NewTLV(j.Tag)
+	if t == nil {
+		return nil, &UnknownTagError{Tag: j.Tag}
+	}
+	if kind, err := ParseDataKind(j.Kind); err != nil {
+		return nil, err
+	} else if kind != t.Kind {
+		return nil, fmt.Errorf("TLV.UnmarshalJSON tag=%d kind=%s expected=%s", j.Tag, j.Kind, t.Kind.String())
+	}
+	t.Caption, t.Printable = j.Caption, j.Printable
+	wrap := func(err error) error { return fmt.Errorf("TLV.UnmarshalJSON tag=%d: %v", j.Tag, err) }
+	if t.Kind == DataKindSTLV {
+		for i := range j.Children {
+			child, err := fromJSON(tags, &j.Children[i])
+			if err != nil {
+				return nil, err
+			}
+			t.Append(child)
+		}
+		return t, nil
+	}
+
+	var v interface{}
+	var err error
+	switch t.Kind {
+	case DataKindBool:
+		var x bool
+		err = json.Unmarshal(j.Value, &x)
+		v = x
+	case DataKindUint, DataKindVLN:
+		var x uint64
+		err = json.Unmarshal(j.Value, &x)
+		v = x
+	case DataKindFVLN:
+		// number token is accepted as well, parsed exactly
+		s := string(j.Value)
+		if len(j.Value) != 0 && j.Value[0] == '"' {
+			err = json.Unmarshal(j.Value, &s)
+		}
+		if err == nil {
+			v, err = ParseDecimal(s)
+		}
+	case DataKindTime, DataKindString:
+		var x string
+		err = json.Unmarshal(j.Value, &x)
+		v = x
+	case DataKindBytes:
+		var x string
+		if err = json.Unmarshal(j.Value, &x); err == nil {
+			v, err = hex.DecodeString(x)
+		}
+	}
+	if err != nil {
+		return nil, wrap(err)
+	}
+	if err := t.TrySetValue(v); err != nil {
+		return nil, wrap(err)
+	}
+	return t, nil
+}
@@ -0,0 +1,84 @@
+package ru_nalog
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocJSON(t *testing.T) {
+	t.Parallel()
+
+	d1 := NewDoc(8493, FDCheck)
+	d1.AppendNew(1054, CalcIncome)
+	d1.AppendNew(1012, time.Date(2020, 1, 25, 6, 18, 19, 0, time.FixedZone("", 3*3600)))
+	d1.AppendNew(1018, "7725225244")
+	d1.AppendNew(1077, []byte{0, 0, 0x69, 0x3c, 0xab, 0xfc})
+	d1.AppendNew(1001, true)
+	row := d1.AppendNew(1059, nil)
+	row.AppendNew(1023, NewDecimal(1500, 3))
+	row.AppendNew(1079, Money(1999)).Caption = "ЦЕНА"
+	row.AppendNew(1020, uint64(1)<<40)
+
+	b, err := json.Marshal(d1)
+	require.NoError(t, err)
+	expect := `{"number":8493,"type":3,"props":[` +
+		`{"tag":1054,"kind":"Uint","value":1},` +
+		`{"tag":1012,"kind":"Time","value":"2020-01-25T06:18:19+03:00"},` +
+		`{"tag":1018,"kind":"String","value":"7725225244"},` +
+		`{"tag":1077,"kind":"Bytes","value":"0000693cabfc"},` +
+		`{"tag":1001,"kind":"Bool","value":true},` +
+		`{"tag":1059,"kind":"STLV","children":[` +
+		`{"tag":1023,"kind":"FVLN","value":"1.500"},` +
+		`{"tag":1079,"kind":"VLN","value":1999,"caption":"ЦЕНА"},` +
+		`{"tag":1020,"kind":"VLN","value":1099511627776}]}]}`
+	assert.Equal(t, expect, string(b))
+
+	d2 := &Doc{}
+	require.NoError(t, json.Unmarshal(b, d2))
+	assert.Equal(t, d1.String(), d2.String())
+	assert.Equal(t, "ЦЕНА", d2.Props.Children()[5].Children()[1].Caption)
+	b2, err := json.Marshal(d2)
+	require.NoError(t, err)
+	assert.Equal(t, expect, string(b2))
+
+	for _, s := range []string{
+		`{"props":[{"tag":1,"kind":"Uint","value":1}]}`,
+		`{"props":[{"tag":1054,"kind":"VLN","value":1}]}`,
+		`{"props":[{"tag":1054,"kind":"Uint","value":"1"}]}`,
+		`{"props":[{"tag":1054,"kind":"Uint"}]}`,
+		`{"props":[{"tag":1077,"kind":"Bytes","value":"xyz"}]}`,
+		`{"props":[{"tag":1059,"kind":"STLV","children":[{"tag":1023,"kind":"FVLN","value":"1,2,3"}]}]}`,
+	} {
+		assert.Error(t, json.Unmarshal([]byte(s), &Doc{}), s)
+	}
+
+	// unknown enum value is decoded as is
+	require.NoError(t, json.Unmarshal([]byte(`{"props":[{"tag":1054,"kind":"Uint","value":9}]}`), d2))
+	assert.Equal(t, CalcSign(9), d2.Get("1054").CalcSign())
+
+	// FVLN number token is parsed exactly
+	tlv := &TLV{}
+	require.NoError(t, json.Unmarshal([]byte(`{"tag":1023,"kind":"FVLN","value":0.1}`), tlv))
+	assert.Equal(t, NewDecimal(1, 1), tlv.Decimal())
+
+	tlv = NewTLV(1023)
+	tlv.SetValue("bad")
+	_, err = json.Marshal(tlv)
+	assert.Error(t, err)
+}
+
+func TestParseDataKind(t *testing.T) {
+	t.Parallel()
+
+	for k := DataKindSTLV; k <= DataKindBytes; k++ {
+		parsed, err := ParseDataKind(k.String())
+		assert.NoError(t, err)
+		assert.Equal(t, k, parsed)
+	}
+	_, err := ParseDataKind("Invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Amount in kopecks, value of sum VLN tags like 1020, 1031, 1043, 1079.
+type Money uint64
+
+// Accepts "2", "2.00", "2,5", "1 333,50". More than two fraction digits is an error.
+func ParseMoney(s string) (Money, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return 0, fmt.Errorf("ParseMoney: %v", err)
+	}
+	return MoneyFromRubles(d)
+}
+
+// 1.5 -> 150 kopecks, error if value has fraction of kopeck or overflows.
+func MoneyFromRubles(d Decimal) (Money, error) {
+	k, err := d.Rescale(2)
+	if err != nil {
+		return 0, fmt.Errorf("MoneyFromRubles: %v", err)
+	}
+	return Money(k.Mantissa), nil
+}
+
+// Exact value in rubles with 2 fraction digits.
+func (m Money) Rubles() Decimal { return Decimal{Mantissa: uint64(m), Point: 2} }
+
+func (m Money) Kopecks() uint64 { return uint64(m) }
+
+// "1333.50"
+func (m Money) String() string { return m.Rubles().String() }
+
+// "1 333,50"
+func (m Money) StringRu() string { return m.Rubles().Format(",", " ") }
+
+func (m Money) Add(other Money) (Money, error) {
+	if uint64(m) > math.MaxUint64-uint64(other) {
+		return 0, fmt.Errorf("Money %s + %s overflow", m, other)
+	}
+	return m + other, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if other > m {
+		return 0, fmt.Errorf("Money %s - %s negative", m, other)
+	}
+	return m - other, nil
+}
+
+// Price * quantity rounded half up to kopeck.
+func (m Money) MulDecimal(q Decimal) (Money, error) {
+	n := new(big.Int).SetUint64(uint64(m))
+	n.Mul(n, new(big.Int).SetUint64(q.Mantissa))
+	if q.Point != 0 {
+		div := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(q.Point)), nil)
+		rem := new(big.Int)
+		n.QuoRem(n, div, rem)
+		if rem.Lsh(rem, 1).Cmp(div) >= 0 {
+			n.Add(n, big.NewInt(1))
+		}
+	}
+	if !n.IsUint64() {
+		return 0, fmt.Errorf("Money %s * %s overflow", m, q)
+	}
+	return Money(n.Uint64()), nil
+}
+
+func (self *TLV) Money() Money { return mustValue(self.TryMoney()).(Money) }
+
+// VLN value as Money.
+func (self *TLV) TryMoney() (Money, error) {
+	if self == nil || self.Kind != DataKindVLN {
+		return 0, self.typeError("Money")
+	}
+	n, err := self.TryUint64()
+	return Money(n), err
+}
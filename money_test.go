@@ -0,0 +1,72 @@
+package ru_nalog
+
+import (
+	"math"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney(t *testing.T) {
+	t.Parallel()
+
+	type Case struct {
+		input  string
+		expect Money
+		err    bool
+	}
+	for _, c := range []Case{
+		{"2", 200, false},
+		{"2,00", 200, false},
+		{"2.00", 200, false},
+		{"2,5", 250, false},
+		{"1 333,50", 133350, false},
+		{"0.01", 1, false},
+		{"0.001", 0, true},
+		{"-1", 0, true},
+		{"", 0, true},
+	} {
+		m, err := ParseMoney(c.input)
+		if c.err {
+			assert.Error(t, err, c.input)
+			continue
+		}
+		assert.NoError(t, err, c.input)
+		assert.Equal(t, c.expect, m, c.input)
+	}
+
+	assert.Equal(t, "2.00", Money(200).String())
+	assert.Equal(t, "2,00", Money(200).StringRu())
+	assert.Equal(t, "1 333,05", Money(133305).StringRu())
+	assert.Equal(t, "0.07", Money(7).String())
+	assert.Equal(t, NewDecimal(133305, 2), Money(133305).Rubles())
+	require.NoError(t, quick.Check(func(n uint64) bool {
+		m, err := ParseMoney(Money(n).String())
+		return err == nil && m == Money(n)
+	}, nil))
+
+	_, err := Money(math.MaxUint64).Add(1)
+	assert.Error(t, err)
+	_, err = Money(1).Sub(2)
+	assert.Error(t, err)
+	m, err := Money(5).Sub(2)
+	assert.NoError(t, err)
+	assert.Equal(t, Money(3), m)
+
+	m, err = Money(1999).MulDecimal(NewDecimal(1500, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, Money(2999), m) // 2998.5 half up
+	m, err = Money(1001).MulDecimal(NewDecimal(333, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, Money(333), m) // 333.333
+	_, err = Money(math.MaxUint64).MulDecimal(NewDecimal(2, 0))
+	assert.Error(t, err)
+
+	tlv := NewTLV(1020)
+	require.NoError(t, tlv.TrySetValue(Money(12345)))
+	assert.Equal(t, Money(12345), tlv.Money())
+	_, err = NewTLV(1023).TryMoney()
+	assert.Error(t, err)
+}
@@ -0,0 +1,350 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Printable form (ПФ) of fiscal document.
+// Doc.PrintLines builds device independent model, PrintLines.Text renders it for monospace printers,
+// other renderers (ESC/POS, HTML) walk the same lines.
+
+type PrintLineKind uint8
+
+const (
+	PrintText      PrintLineKind = iota + 1 // Caption on the left, Value on the right
+	PrintTitle                              // Value centered, bold
+	PrintSeparator                          // horizontal rule
+	PrintQR                                 // Value is QR code payload
+)
+
+var printLineKindNames = [...]string{
+	PrintText:      "text",
+	PrintTitle:     "title",
+	PrintSeparator: "separator",
+	PrintQR:        "qr",
+}
+
+func (k PrintLineKind) String() string {
+	if k == 0 || int(k) >= len(printLineKindNames) {
+		return fmt.Sprintf("PrintLineKind(%d)", k)
+	}
+	return printLineKindNames[k]
+}
+
+type PrintLine struct {
+	Kind    PrintLineKind
+	Tag     Tag // source attribute, 0 for decoration
+	Caption string
+	Value   string
+	Bold    bool
+}
+
+type PrintLines []PrintLine
+
+// Characters per line of 80mm printers, 58mm ones have 32.
+const DefaultPrintWidth = 42
+
+var docTitles = map[DocType]string{
+	FDRegistration:         "ОТЧЕТ О РЕГИСТРАЦИИ",
+	FDRegChange:            "ОТЧЕТ ОБ ИЗМ. ПАРАМЕТРОВ РЕГИСТРАЦИИ",
+	FDCycleOpen:            "ОТЧЕТ ОБ ОТКРЫТИИ СМЕНЫ",
+	FDStateReport:          "ОТЧЕТ О ТЕКУЩЕМ СОСТОЯНИИ РАСЧЕТОВ",
+	FDCheck:                "КАССОВЫЙ ЧЕК",
+	FDCorrectionCheck:      "КАССОВЫЙ ЧЕК КОРРЕКЦИИ",
+	FDBSO:                  "БСО",
+	FDCorrectionBSO:        "БСО КОРРЕКЦИИ",
+	FDCycleClose:           "ОТЧЕТ О ЗАКРЫТИИ СМЕНЫ",
+	FDStorageClose:         "ОТЧЕТ О ЗАКРЫТИИ ФН",
+	FDOperatorConfirmation: "ПОДТВЕРЖДЕНИЕ ОПЕРАТОРА",
+}
+
+// Printed without caption, others use TagDesc.PrintCaption or tag number.
+var printValueOnly = []Tag{
+	TagPaymentAddress, TagDateTime, TagItemName, TagUserName, TagCalcSign, TagItem,
+	TagUserProp, TagCorrectionDescription, TagQRCode, TagVATRate, TagPaymentSubject, TagPaymentMethod,
+}
+
+// VLN sums and prices printed as rubles, other VLN values are plain numbers.
+var printMoneyTags = []Tag{
+	TagTotalSum, TagCashSum, TagItemSum, TagPrice, TagElectronicSum, TagPrepaidSum, TagCreditSum, TagOtherPaymentSum,
+	TagCheckVAT20, TagCheckVAT10, TagCheckSumVAT0, TagCheckSumNoVAT, TagCheckVAT20120, TagCheckVAT10110,
+	TagUnitVAT, TagItemVAT, TagExcise,
+	TagCashTotal, TagElectronicTotal, TagPrepaidTotal, TagCreditTotal, TagOtherPaymentTotal, TagChecksTotal,
+	TagVAT20Total, TagVAT10Total, TagVAT20120Total, TagVAT10110Total, TagSumVAT0Total, TagSumNoVATTotal,
+	TagCorrectionVAT20Total, TagCorrectionVAT10Total, TagCorrectionVAT20120Total, TagCorrectionVAT10110Total,
+	TagCorrectionSumVAT0Total, TagCorrectionSumNoVATTotal,
+}
+
+var (
+	printHeaderTags = []Tag{
+		TagUserName, TagPaymentAddress, TagPaymentPlace, TagUserINN, TagAutomatNumber, TagDateTime,
+		TagCheckNumber, TagCycleNumber, TagCashier, TagCashierINN, TagCalcSign,
+	}
+	printTotalTags = []Tag{
+		TagTotalSum, TagCashSum, TagElectronicSum, TagPrepaidSum, TagCreditSum, TagOtherPaymentSum,
+		TagCheckVAT20, TagCheckVAT10, TagCheckSumVAT0, TagCheckSumNoVAT, TagCheckVAT20120, TagCheckVAT10110,
+	}
+	printFooterTags = []Tag{
+		TagTaxSystem, TagKKTRegNumber, TagKKTSerial, TagFNNumber, TagDocNumber, TagFiscalSign,
+		TagKKTFFDVersion, TagCustomerContact, TagSenderEmail, TagFNSSite,
+	}
+	// item block: name, "quantity x price =sum", then the rest
+	printItemTags = []Tag{TagItemName, TagQuantity, TagUnit, TagPrice, TagItemSum}
+)
+
+// Printable form: header, items, totals, other attributes, registration data and QR payload.
+// Attributes with error values are skipped.
+func (d *Doc) PrintLines() PrintLines {
+	title, ok := docTitles[d.Type]
+	if !ok {
+		title = fmt.Sprintf("ДОКУМЕНТ %d", d.Type)
+	}
+	p := printer{done: make(map[Tag]bool, 32)}
+	p.add(PrintLine{Kind: PrintTitle, Value: title, Bold: true})
+	props := d.Props.Children()
+	p.tags(props, printHeaderTags)
+	for i := range props {
+		if props[i].Tag == TagItem {
+			p.add(PrintLine{Kind: PrintSeparator})
+			p.item(&props[i])
+		}
+	}
+	p.add(PrintLine{Kind: PrintSeparator})
+	p.tags(props, printTotalTags)
+	for i := range props {
+		tag := props[i].Tag
+		if !p.done[tag] && tag != TagItem && tag != TagQRCode && !tagIn(tag, printFooterTags) {
+			p.tlv(&props[i])
+		}
+	}
+	p.add(PrintLine{Kind: PrintSeparator})
+	p.tags(props, printFooterTags)
+
+	if t := d.FindByTag(TagQRCode); t != nil && t.Err() == nil {
+		p.add(PrintLine{Kind: PrintQR, Tag: TagQRCode, Value: t.String()})
+	} else if d.Type == FDCheck || d.Type == FDCorrectionCheck || d.Type == FDBSO || d.Type == FDCorrectionBSO {
+		if q, err := NewReceiptQR(d); err == nil {
+			p.add(PrintLine{Kind: PrintQR, Tag: TagQRCode, Value: q.String()})
+		}
+	}
+	return p.lines
+}
+
+// Monospace text, lines are separated and terminated by "\n".
+// width <= 0 means DefaultPrintWidth.
+func (ls PrintLines) Text(width int) string {
+	b := strings.Builder{}
+	for _, l := range ls {
+		for _, s := range l.Text(width) {
+			b.WriteString(s)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// Wraps line to at most width runes each.
+// Caption and Value share line when they fit, otherwise Value is on next lines aligned right.
+func (l PrintLine) Text(width int) []string {
+	if width <= 0 {
+		width = DefaultPrintWidth
+	}
+	switch l.Kind {
+	case PrintSeparator:
+		return []string{strings.Repeat("-", width)}
+	case PrintTitle:
+		ss := wrapText(l.Value, width)
+		for i, s := range ss {
+			ss[i] = strings.Repeat(" ", (width-utf8.RuneCountInString(s))/2) + s
+		}
+		return ss
+	case PrintQR:
+		return wrapText(l.Value, width)
+	}
+	cw, vw := utf8.RuneCountInString(l.Caption), utf8.RuneCountInString(l.Value)
+	switch {
+	case l.Caption == "":
+		return wrapText(l.Value, width)
+	case l.Value == "":
+		return wrapText(l.Caption, width)
+	case cw+1+vw <= width:
+		return []string{l.Caption + strings.Repeat(" ", width-cw-vw) + l.Value}
+	}
+	ss := wrapText(l.Caption, width)
+	for _, s := range wrapText(l.Value, width) {
+		ss = append(ss, strings.Repeat(" ", width-utf8.RuneCountInString(s))+s)
+	}
+	return ss
+}
+
+// Splits by words, words longer than width are broken.
+func wrapText(s string, width int) []string {
+	var ss []string
+	line := []rune{}
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		if len(line) != 0 && len(line)+1+len(w) > width {
+			ss = append(ss, string(line))
+			line = line[:0]
+		}
+		if len(line) != 0 {
+			line = append(line, ' ')
+		}
+		for len(line)+len(w) > width {
+			n := width - len(line)
+			ss = append(ss, string(append(line, w[:n]...)))
+			line, w = line[:0], w[n:]
+		}
+		line = append(line, w...)
+	}
+	if len(line) != 0 {
+		ss = append(ss, string(line))
+	}
+	return ss
+}
+
+type printer struct {
+	lines PrintLines
+	done  map[Tag]bool
+}
+
+func (p *printer) add(l PrintLine) { p.lines = append(p.lines, l) }
+
+// Prints all children with tags in given order, once.
+func (p *printer) tags(children []TLV, tags []Tag) {
+	for _, tag := range tags {
+		if p.done[tag] {
+			continue
+		}
+		p.done[tag] = true
+		for i := range children {
+			if children[i].Tag == tag {
+				p.tlv(&children[i])
+			}
+		}
+	}
+}
+
+func (p *printer) item(row *TLV) {
+	if name := findChild(row, TagItemName); name != nil && name.Err() == nil {
+		p.add(PrintLine{Kind: PrintText, Tag: TagItemName, Value: name.String()})
+	}
+	quantity, price := findChild(row, TagQuantity), findChild(row, TagPrice)
+	if quantity != nil && price != nil {
+		q, qerr := quantity.TryDecimal()
+		m, merr := price.TryMoney()
+		if qerr == nil && merr == nil {
+			caption := q.normalize().Format(",", "")
+			if unit := findChild(row, TagUnit); unit != nil && unit.Err() == nil {
+				caption += " " + unit.String()
+			}
+			caption += " x " + m.StringRu()
+			sum, err := m.MulDecimal(q)
+			if t := findChild(row, TagItemSum); t != nil {
+				sum, err = t.TryMoney()
+			}
+			if err == nil {
+				p.add(PrintLine{Kind: PrintText, Tag: TagItemSum, Caption: caption, Value: "=" + sum.StringRu()})
+			}
+		}
+	}
+	cs := row.Children()
+	for i := range cs {
+		if !tagIn(cs[i].Tag, printItemTags) {
+			p.tlv(&cs[i])
+		}
+	}
+}
+
+func (p *printer) tlv(t *TLV) {
+	value, ok := printValue(t)
+	if !ok {
+		return
+	}
+	caption := t.PrintCaption
+	if caption == "" && !tagIn(t.Tag, printValueOnly) {
+		caption = strconv.Itoa(int(t.Tag))
+	}
+	if _, enum := enumTags[t.Tag]; enum && caption == "" {
+		value = strings.ToUpper(value)
+	}
+	switch t.Tag {
+	case TagCalcSign:
+		p.add(PrintLine{Kind: PrintTitle, Tag: t.Tag, Value: value, Bold: true})
+		return
+	case TagUserProp:
+		// additional user attribute: name and value
+		name, v := findChild(t, TagUserPropName), findChild(t, TagUserPropValue)
+		if name != nil && v != nil && name.Err() == nil && v.Err() == nil {
+			p.add(PrintLine{Kind: PrintText, Tag: t.Tag, Caption: name.String(), Value: v.String()})
+			return
+		}
+	}
+	if t.Kind == DataKindSTLV {
+		if caption != "" {
+			p.add(PrintLine{Kind: PrintText, Tag: t.Tag, Caption: caption})
+		}
+		cs := t.Children()
+		for i := range cs {
+			p.tlv(&cs[i])
+		}
+		return
+	}
+	p.add(PrintLine{Kind: PrintText, Tag: t.Tag, Caption: caption, Value: value, Bold: t.Tag == TagTotalSum})
+}
+
+// Value in print format, false for error values.
+func printValue(t *TLV) (string, bool) {
+	if t.Err() != nil {
+		return "", false
+	}
+	if e, ok := enumTags[t.Tag]; ok {
+		n, _ := toUint64(t.value)
+		return e.new(n).StringRu(), true
+	}
+	switch t.Tag {
+	case TagFiscalSign:
+		fp, err := fiscalSign(t)
+		return fmt.Sprintf("%010d", fp), err == nil
+	case TagKKTFFDVersion, TagFNFFDVersion, TagFFDVersion:
+		n, _ := toUint64(t.value)
+		return FFDVersion(n).String(), true
+	}
+	switch t.Kind {
+	case DataKindBool:
+		if t.Bool() {
+			return "ДА", true
+		}
+		return "НЕТ", true
+	case DataKindBytes:
+		return fmt.Sprintf("%X", t.Bytes()), true
+	case DataKindFVLN:
+		return t.Decimal().normalize().Format(",", ""), true
+	case DataKindVLN:
+		if tagIn(t.Tag, printMoneyTags) {
+			return Money(t.Uint64()).StringRu(), true
+		}
+		return strconv.FormatUint(t.Uint64(), 10), true
+	case DataKindUint:
+		return strconv.FormatUint(uint64(t.Uint32()), 10), true
+	case DataKindString:
+		return t.String(), true
+	case DataKindTime:
+		return t.Time().Format("02.01.06 15:04"), true
+	case DataKindSTLV:
+		return "", true
+	}
+	return "", false
+}
+
+func tagIn(tag Tag, tags []Tag) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+package ru_nalog
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPrintDoc(t *testing.T) *Doc {
+	d, err := (&Receipt{
+		Operation: CalcIncome,
+		TaxSystem: TaxSimplifiedIncome,
+		Cashier:   "КАССИР 70",
+		Items:     []Item{{Name: "FIXME_code", Price: 200, Quantity: NewDecimal(1000, 3), VATRate: VATNone, PaymentMethod: MethodFullPrepayment, PaymentSubject: SubjectCommodity}},
+		Payments:  []Payment{{Type: PaymentCash, Sum: 200}},
+	}).Doc()
+	require.NoError(t, err)
+	require.NoError(t, d.ComputeTotals())
+	d.Number = 8493
+	d.AppendNew(1048, "Армакс")
+	d.AppendNew(1009, "Адрес расчетов")
+	d.AppendNew(1187, "Место расчетов")
+	d.AppendNew(1018, "7725225244")
+	d.AppendNew(1012, time.Date(2020, 1, 25, 6, 18, 19, 0, time.FixedZone("MSK", 3*3600)))
+	d.AppendNew(1042, 1)
+	d.AppendNew(1038, 372)
+	d.AppendNew(1037, "0329868379061673")
+	d.AppendNew(1013, "16999987")
+	d.AppendNew(1041, "9999078900003063")
+	d.AppendNew(1040, 8493)
+	d.AppendNew(1077, []byte{0, 0, 0x69, 0x3c, 0xab, 0xfc})
+	d.AppendNew(1189, FFD105)
+	d.AppendNew(1117, "aaa@bbb.ru")
+	d.AppendNew(1060, "nalog.ru")
+	return d
+}
+
+func TestPrintLines(t *testing.T) {
+	t.Parallel()
+
+	d := newTestPrintDoc(t)
+	expect := `          КАССОВЫЙ ЧЕК
+Армакс
+Адрес расчетов
+МЕСТО РАСЧЕТОВ    Место расчетов
+ИНН                   7725225244
+25.01.20 06:18
+ЧЕК                            1
+СМЕНА                        372
+КАССИР                 КАССИР 70
+             ПРИХОД
+--------------------------------
+FIXME_code
+1 x 2,00                   =2,00
+БЕЗ НДС
+ПРЕДОПЛАТА 100%
+ТОВАР
+--------------------------------
+ИТОГ                        2,00
+НАЛИЧНЫМИ                   2,00
+СУММА БЕЗ НДС               2,00
+--------------------------------
+СНО                    УСН доход
+РН ККТ          0329868379061673
+ЗН ККТ                  16999987
+ФН              9999078900003063
+ФД                          8493
+ФП                    1765583868
+ФФД ККТ                     1.05
+ЭЛ. АДР. ОТПРАВИТЕЛЯ  aaa@bbb.ru
+САЙТ ФНС                nalog.ru
+t=20200125T0618&s=2.00&fn=999907
+8900003063&i=8493&fp=1765583868&
+n=1
+`
+	lines := d.PrintLines()
+	assert.Equal(t, expect, lines.Text(32))
+	assert.Equal(t, PrintLine{Kind: PrintQR, Tag: 1196, Value: "t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1"}, lines[len(lines)-1])
+	assert.Equal(t, PrintLine{Kind: PrintText, Tag: 1020, Caption: "ИТОГ", Value: "2,00", Bold: true}, lines[17])
+
+	for _, width := range []int{0, 32, 42, 48} {
+		text := lines.Text(width)
+		if width == 0 {
+			width = DefaultPrintWidth
+		}
+		for _, s := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+			assert.True(t, utf8.RuneCountInString(s) <= width, "width=%d line=%q", width, s)
+		}
+	}
+
+	// unknown caption, bool, error values are skipped
+	d = NewDoc(1, FDCycleOpen)
+	d.AppendNew(1051, true)
+	d.AppendNew(1213, 7)
+	d.AppendNew(1021, strings.Repeat("я", 65))
+	lines = d.PrintLines()
+	assert.Equal(t, "   ОТЧЕТ ОБ\nОТКРЫТИИ СМЕНЫ\n--------------\nЗАМЕНИТЕ ФН ДА\n1213         7\n--------------\n", lines.Text(14))
+
+	// only sums and prices of VLN are money
+	tags12 := TagsForVersion(FFD12)
+	for _, c := range []struct {
+		tag    Tag
+		expect string
+	}{{1229, "0,03"}, {1200, "0,03"}, {1293, "3"}, {1294, "3"}} {
+		tlv := tags12.NewTLV(c.tag)
+		tlv.SetValue(3)
+		s, ok := printValue(tlv)
+		assert.True(t, ok)
+		assert.Equal(t, c.expect, s, "tag=%d", c.tag)
+	}
+}
+
+func TestPrintLineText(t *testing.T) {
+	t.Parallel()
+
+	type Case struct {
+		line  PrintLine
+		width int
+		lines []string
+	}
+	for _, c := range []Case{
+		{PrintLine{Kind: PrintText, Caption: "ИТОГ", Value: "2,00"}, 10, []string{"ИТОГ  2,00"}},
+		{PrintLine{Kind: PrintText, Caption: "ИТОГ", Value: "12,00"}, 10, []string{"ИТОГ 12,00"}},
+		{PrintLine{Kind: PrintText, Caption: "ИТОГ", Value: "123,00"}, 10, []string{"ИТОГ", "    123,00"}},
+		{PrintLine{Kind: PrintText, Value: "очень длинное наименование"}, 10, []string{"очень", "длинное", "наименован", "ие"}},
+		{PrintLine{Kind: PrintText, Caption: "a b", Value: ""}, 10, []string{"a b"}},
+		{PrintLine{Kind: PrintText}, 10, nil},
+		{PrintLine{Kind: PrintTitle, Value: "ЧЕК"}, 10, []string{"   ЧЕК"}},
+		{PrintLine{Kind: PrintSeparator}, 3, []string{"---"}},
+		{PrintLine{Kind: PrintQR, Value: "t=1&s=2"}, 3, []string{"t=1", "&s=", "2"}},
+	} {
+		assert.Equal(t, c.lines, c.line.Text(c.width), "%#v", c.line)
+	}
+}
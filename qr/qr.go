@@ -0,0 +1,223 @@
+// Package qr is a minimal QR code (model 2) encoder for fiscal check payloads.
+// Byte mode only, versions 1-40, automatic mask selection.
+package qr
+
+import "fmt"
+
+// Error correction level.
+type Level uint8
+
+const (
+	L Level = iota // 7% recovery
+	M              // 15%
+	Q              // 25%
+	H              // 30%
+)
+
+func (l Level) String() string {
+	if l > H {
+		return fmt.Sprintf("Level(%d)", l)
+	}
+	return "LMQH"[l : l+1]
+}
+
+// Format information bits of level.
+var levelBits = [...]uint32{L: 1, M: 0, Q: 3, H: 2}
+
+// Encoded symbol, modules without quiet zone.
+type Code struct {
+	Size    int // modules per side, 17+4*Version
+	Version int
+	Level   Level
+	Mask    int
+	modules []bool
+}
+
+// Reports whether module at column x, row y is dark. Outside of symbol is light.
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y*c.Size+x]
+}
+
+// Encodes data in byte mode with smallest version fitting level.
+func Encode(data string, level Level) (*Code, error) {
+	if level > H {
+		return nil, fmt.Errorf("qr.Encode invalid level=%d", level)
+	}
+	version := 0
+	for v := 1; v <= 40; v++ {
+		if 4+countBits(v)+8*len(data) <= 8*dataCodewords(v, level) {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("qr.Encode data length=%d too long for level=%s", len(data), level)
+	}
+
+	bb := bitBuffer{}
+	bb.append(4, 4) // byte mode
+	bb.append(uint32(len(data)), countBits(version))
+	for i := 0; i < len(data); i++ {
+		bb.append(uint32(data[i]), 8)
+	}
+	capacity := 8 * dataCodewords(version, level)
+	terminator := capacity - bb.n
+	if terminator > 4 {
+		terminator = 4
+	}
+	bb.append(0, terminator)
+	bb.append(0, (8-bb.n%8)%8)
+	for pad := uint32(0xec); bb.n < capacity; pad ^= 0xec ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	s := newSymbol(version, level)
+	s.drawFunctions()
+	s.drawCodewords(addECC(bb.bytes, version, level))
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		s.applyMask(mask)
+		s.drawFormat(mask)
+		if p := s.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		s.applyMask(mask) // xor back
+	}
+	s.applyMask(best)
+	s.drawFormat(best)
+	return &Code{Size: s.size, Version: version, Level: level, Mask: best, modules: s.modules}, nil
+}
+
+type bitBuffer struct {
+	bytes []byte
+	n     int
+}
+
+func (bb *bitBuffer) append(value uint32, bits int) {
+	for i := bits - 1; i >= 0; i-- {
+		if bb.n%8 == 0 {
+			bb.bytes = append(bb.bytes, 0)
+		}
+		if value>>uint(i)&1 != 0 {
+			bb.bytes[bb.n/8] |= 0x80 >> uint(bb.n%8)
+		}
+		bb.n++
+	}
+}
+
+// Character count indicator length of byte mode.
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// Index 0 is unused.
+var eccPerBlock = [4][41]int{
+	L: {0, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	M: {0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	Q: {0, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	H: {0, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+var eccBlocks = [4][41]int{
+	L: {0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	M: {0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	Q: {0, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	H: {0, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Modules available for data and ECC codewords, including remainder bits.
+func rawDataModules(version int) int {
+	n := (16*version+128)*version + 64
+	if version >= 2 {
+		align := version/7 + 2
+		n -= (25*align-10)*align - 55
+		if version >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+func dataCodewords(version int, level Level) int {
+	return rawDataModules(version)/8 - eccPerBlock[level][version]*eccBlocks[level][version]
+}
+
+// Splits data into blocks, appends Reed-Solomon ECC and interleaves.
+func addECC(data []byte, version int, level Level) []byte {
+	numBlocks, eccLen := eccBlocks[level][version], eccPerBlock[level][version]
+	raw := rawDataModules(version) / 8
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw / numBlocks
+	divisor := rsDivisor(eccLen)
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := range blocks {
+		n := shortLen - eccLen
+		if i >= numShort {
+			n++
+		}
+		block := make([]byte, 0, shortLen+1)
+		block = append(block, data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(block, divisor)
+		if i < numShort {
+			block = append(block, 0) // skipped when interleaving
+		}
+		blocks[i] = append(block, ecc...)
+	}
+	result := make([]byte, 0, raw)
+	for i := 0; i < len(blocks[0]); i++ {
+		for j := range blocks {
+			if i != shortLen-eccLen || j >= numShort {
+				result = append(result, blocks[j][i])
+			}
+		}
+	}
+	return result
+}
+
+// Generator polynomial coefficients, highest degree first without leading 1.
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMul(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMul(root, 2)
+	}
+	return result
+}
+
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i := range result {
+			result[i] ^= gfMul(divisor[i], factor)
+		}
+	}
+	return result
+}
+
+// GF(2^8) with polynomial 0x11d
+func gfMul(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
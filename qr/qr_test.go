@@ -0,0 +1,162 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReedSolomon(t *testing.T) {
+	t.Parallel()
+
+	// "HELLO WORLD" 1-M, ISO/IEC 18004 annex I
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ecc := rsRemainder(data, rsDivisor(10))
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ecc)
+}
+
+func TestFormatVersionBits(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, uint32(0x77c4), formatBits(L, 0)) // 111011111000100
+	assert.Equal(t, uint32(0x5412), formatBits(M, 0)) // 101010000010010
+	assert.Equal(t, uint32(0x355f), formatBits(Q, 0)) // 011010101011111
+	assert.Equal(t, uint32(0x1689), formatBits(H, 0)) // 001011010001001
+	assert.Equal(t, uint32(0x07c94), versionBits(7))
+	assert.Equal(t, uint32(0x28c69), versionBits(40))
+	assert.Equal(t, []int{6, 34, 60, 86, 112, 138}, alignmentPositions(32))
+	assert.Equal(t, []int{6, 22, 38}, alignmentPositions(7))
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	payload := "t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1"
+	for _, level := range []Level{L, M, Q, H} {
+		c, err := Encode(payload, level)
+		require.NoError(t, err)
+		assert.Equal(t, 17+4*c.Version, c.Size)
+		assert.Equal(t, payload, decode(t, c), "level=%s", level)
+	}
+
+	c, err := Encode(payload, M)
+	require.NoError(t, err)
+	assert.Equal(t, 5, c.Version)
+	// finder corners
+	assert.True(t, c.Black(0, 0))
+	assert.False(t, c.Black(1, 1))
+	assert.True(t, c.Black(2, 2))
+	assert.False(t, c.Black(7, 7))
+	assert.True(t, c.Black(8, c.Size-8))
+
+	long := strings.Repeat("x", 1000)
+	c, err = Encode(long, H)
+	require.NoError(t, err)
+	assert.Equal(t, long, decode(t, c))
+	assert.True(t, c.Version >= 7)
+
+	_, err = Encode(strings.Repeat("x", 3000), L)
+	assert.Error(t, err)
+	_, err = Encode("", 7)
+	assert.Error(t, err)
+}
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	c, err := Encode("hello", M)
+	require.NoError(t, err)
+	b, err := c.PNG(300)
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	// 21+8 modules, 10 px each
+	assert.Equal(t, 290, img.Bounds().Dx())
+	r, _, _, _ := img.At(0, 0).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+	r, _, _, _ = img.At(40, 40).RGBA()
+	assert.Equal(t, uint32(0), r)
+	assert.Equal(t, 29*4, c.Image(0).Bounds().Dx())
+	assert.Equal(t, 29, c.Image(1).Bounds().Dx())
+
+	svg := string(c.SVG(200))
+	assert.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="200" height="200" viewBox="0 0 29 29"`))
+	assert.Contains(t, svg, "M4 4h1v1h-1z")
+}
+
+// Reads symbol back: format, unmask, deinterleave, check ECC, parse byte mode segment.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+	s := newSymbol(c.Version, c.Level)
+	s.drawFunctions()
+	format := uint32(0)
+	for i := 0; i <= 5; i++ {
+		format |= bit(c.Black(8, i)) << uint(i)
+	}
+	format |= bit(c.Black(8, 7))<<6 | bit(c.Black(8, 8))<<7 | bit(c.Black(7, 8))<<8
+	for i := 9; i < 15; i++ {
+		format |= bit(c.Black(14-i, 8)) << uint(i)
+	}
+	require.Equal(t, formatBits(c.Level, c.Mask), format)
+
+	f := maskFuncs[c.Mask]
+	bits := make([]bool, 0, rawDataModules(c.Version))
+	s.walkData(func(x, y int) { bits = append(bits, c.Black(x, y) != f(x, y)) })
+	raw := make([]byte, rawDataModules(c.Version)/8)
+	for i := range raw {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				raw[i] |= 0x80 >> uint(j)
+			}
+		}
+	}
+
+	numBlocks, eccLen := eccBlocks[c.Level][c.Version], eccPerBlock[c.Level][c.Version]
+	numShort := numBlocks - len(raw)%numBlocks
+	shortLen := len(raw) / numBlocks
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortLen+1; i++ {
+		for j := range blocks {
+			if i == shortLen-eccLen && j < numShort {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+	divisor := rsDivisor(eccLen)
+	data := []byte{}
+	for _, block := range blocks {
+		n := len(block) - eccLen
+		require.Equal(t, block[n:], rsRemainder(block[:n], divisor))
+		data = append(data, block[:n]...)
+	}
+
+	require.Equal(t, byte(0x40), data[0]&0xf0, "byte mode")
+	read := func(pos, bits int) int {
+		v := 0
+		for i := 0; i < bits; i++ {
+			v = v<<1 | int(data[(pos+i)/8]>>uint(7-(pos+i)%8)&1)
+		}
+		return v
+	}
+	cb := countBits(c.Version)
+	n := read(4, cb)
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(read(4+cb+8*i, 8))
+	}
+	return string(out)
+}
+
+func bit(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Light modules around symbol required by readers.
+const QuietZone = 4
+
+// Pixels per module to fit size, at least 1. Size 0 means 4 pixels per module.
+func (c *Code) scale(size int) int {
+	if size <= 0 {
+		return 4
+	}
+	if s := size / (c.Size + 2*QuietZone); s >= 1 {
+		return s
+	}
+	return 1
+}
+
+// Black and white image with quiet zone. Side is size rounded down to whole modules.
+func (c *Code) Image(size int) *image.Gray {
+	scale := c.scale(size)
+	side := (c.Size + 2*QuietZone) * scale
+	img := image.NewGray(image.Rect(0, 0, side, side))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.Black(x, y) {
+				continue
+			}
+			for py := 0; py < scale; py++ {
+				for px := 0; px < scale; px++ {
+					img.SetGray((x+QuietZone)*scale+px, (y+QuietZone)*scale+py, color.Gray{})
+				}
+			}
+		}
+	}
+	return img
+}
+
+func (c *Code) PNG(size int) ([]byte, error) {
+	b := bytes.Buffer{}
+	if err := png.Encode(&b, c.Image(size)); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// SVG document of given width and height in pixels, scalable without blur.
+// Size 0 means 4 pixels per module.
+func (c *Code) SVG(size int) []byte {
+	side := c.Size + 2*QuietZone
+	if size <= 0 {
+		size = side * 4
+	}
+	b := bytes.Buffer{}
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, side, side)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, side, side)
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if c.Black(x, y) {
+				fmt.Fprintf(&b, "M%d %dh1v1h-1z", x+QuietZone, y+QuietZone)
+			}
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.Bytes()
+}
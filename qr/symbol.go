@@ -0,0 +1,282 @@
+package qr
+
+type symbol struct {
+	size     int
+	version  int
+	level    Level
+	modules  []bool
+	function []bool // finder, timing, alignment, format and version areas
+}
+
+func newSymbol(version int, level Level) *symbol {
+	size := 17 + 4*version
+	return &symbol{
+		size:     size,
+		version:  version,
+		level:    level,
+		modules:  make([]bool, size*size),
+		function: make([]bool, size*size),
+	}
+}
+
+func (s *symbol) get(x, y int) bool { return s.modules[y*s.size+x] }
+
+func (s *symbol) setFunction(x, y int, dark bool) {
+	s.modules[y*s.size+x] = dark
+	s.function[y*s.size+x] = true
+}
+
+func (s *symbol) drawFunctions() {
+	for i := 0; i < s.size; i++ {
+		s.setFunction(6, i, i%2 == 0)
+		s.setFunction(i, 6, i%2 == 0)
+	}
+	s.drawFinder(3, 3)
+	s.drawFinder(s.size-4, 3)
+	s.drawFinder(3, s.size-4)
+	pos := alignmentPositions(s.version)
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			s.drawAlignment(pos[i], pos[j])
+		}
+	}
+	s.drawFormat(0) // reserve area
+	s.drawVersion()
+}
+
+// Finder with separator, center at x,y.
+func (s *symbol) drawFinder(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= s.size || yy >= s.size {
+				continue
+			}
+			d := maxAbs(dx, dy)
+			s.setFunction(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (s *symbol) drawAlignment(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			s.setFunction(x+dx, y+dy, maxAbs(dx, dy) != 1)
+		}
+	}
+}
+
+func alignmentPositions(version int) []int {
+	if version == 1 {
+		return nil
+	}
+	num := version/7 + 2
+	step := (version*8 + num*3 + 5) / (num*4 - 4) * 2
+	pos := make([]int, num)
+	pos[0] = 6
+	for i, p := num-1, 17+4*version-7; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// 15 bits: level, mask and BCH(15,5), see ISO/IEC 18004 7.9
+func formatBits(level Level, mask int) uint32 {
+	data := levelBits[level]<<3 | uint32(mask)
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (s *symbol) drawFormat(mask int) {
+	bits := formatBits(s.level, mask)
+	bit := func(i int) bool { return bits>>uint(i)&1 != 0 }
+	for i := 0; i <= 5; i++ {
+		s.setFunction(8, i, bit(i))
+	}
+	s.setFunction(8, 7, bit(6))
+	s.setFunction(8, 8, bit(7))
+	s.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		s.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		s.setFunction(s.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		s.setFunction(8, s.size-15+i, bit(i))
+	}
+	s.setFunction(8, s.size-8, true) // dark module
+}
+
+// 18 bits: version and BCH(18,6), versions 7 and above.
+func versionBits(version int) uint32 {
+	rem := uint32(version)
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1f25)
+	}
+	return uint32(version)<<12 | rem
+}
+
+func (s *symbol) drawVersion() {
+	if s.version < 7 {
+		return
+	}
+	bits := versionBits(s.version)
+	for i := 0; i < 18; i++ {
+		dark := bits>>uint(i)&1 != 0
+		a, b := s.size-11+i%3, i/3
+		s.setFunction(a, b, dark)
+		s.setFunction(b, a, dark)
+	}
+}
+
+// Zigzag placement in two module wide columns from bottom right, skipping vertical timing.
+func (s *symbol) drawCodewords(data []byte) {
+	i := 0
+	s.walkData(func(x, y int) {
+		if i < len(data)*8 {
+			s.modules[y*s.size+x] = data[i/8]>>uint(7-i%8)&1 != 0
+		}
+		i++
+	})
+}
+
+func (s *symbol) walkData(fn func(x, y int)) {
+	for right := s.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < s.size; vert++ {
+			y := vert
+			if upward {
+				y = s.size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				x := right - j
+				if !s.function[y*s.size+x] {
+					fn(x, y)
+				}
+			}
+		}
+	}
+}
+
+var maskFuncs = [8]func(x, y int) bool{
+	func(x, y int) bool { return (x+y)%2 == 0 },
+	func(x, y int) bool { return y%2 == 0 },
+	func(x, y int) bool { return x%3 == 0 },
+	func(x, y int) bool { return (x+y)%3 == 0 },
+	func(x, y int) bool { return (x/3+y/2)%2 == 0 },
+	func(x, y int) bool { return x*y%2+x*y%3 == 0 },
+	func(x, y int) bool { return (x*y%2+x*y%3)%2 == 0 },
+	func(x, y int) bool { return ((x+y)%2+x*y%3)%2 == 0 },
+}
+
+// XOR, applying twice restores modules.
+func (s *symbol) applyMask(mask int) {
+	f := maskFuncs[mask]
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			if !s.function[y*s.size+x] && f(x, y) {
+				s.modules[y*s.size+x] = !s.modules[y*s.size+x]
+			}
+		}
+	}
+}
+
+// Penalty score, ISO/IEC 18004 7.8.3
+func (s *symbol) penalty() int {
+	result := 0
+	line := make([]bool, s.size)
+	for _, vertical := range []bool{false, true} {
+		for a := 0; a < s.size; a++ {
+			for b := 0; b < s.size; b++ {
+				if vertical {
+					line[b] = s.get(a, b)
+				} else {
+					line[b] = s.get(b, a)
+				}
+			}
+			result += linePenalty(line)
+		}
+	}
+	dark := 0
+	for y := 0; y < s.size; y++ {
+		for x := 0; x < s.size; x++ {
+			c := s.get(x, y)
+			if c {
+				dark++
+			}
+			if x+1 < s.size && y+1 < s.size && c == s.get(x+1, y) && c == s.get(x, y+1) && c == s.get(x+1, y+1) {
+				result += 3
+			}
+		}
+	}
+	total := s.size * s.size
+	k := (absInt(dark*20-total*10)+total-1)/total - 1
+	return result + k*10
+}
+
+var finderLike = [...]bool{true, false, true, true, true, false, true}
+
+// Runs of 5+ same color and 1:1:3:1:1 patterns with 4 light modules on one side.
+func linePenalty(line []bool) int {
+	result := 0
+	run := 1
+	for i := 1; i <= len(line); i++ {
+		if i < len(line) && line[i] == line[i-1] {
+			run++
+			continue
+		}
+		if run >= 5 {
+			result += 3 + run - 5
+		}
+		run = 1
+	}
+	for i := 0; i+7 <= len(line); i++ {
+		match := true
+		for j, dark := range finderLike {
+			if line[i+j] != dark {
+				match = false
+				break
+			}
+		}
+		if match && (lightRun(line, i-4, i) || lightRun(line, i+7, i+11)) {
+			result += 40
+		}
+	}
+	return result
+}
+
+// Modules outside of line count as light quiet zone.
+func lightRun(line []bool, from, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < len(line) && line[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func maxAbs(a, b int) int {
+	a, b = absInt(a), absInt(b)
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
@@ -0,0 +1,132 @@
+package ru_nalog
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Returned by Walk callback to not descend into STLV.
+var SkipChildren = errors.New("skip children")
+
+// All nodes with tag, depth first, including self. Pointers are into tree storage.
+func (self *TLV) FindAll(tag Tag) []*TLV {
+	var result []*TLV
+	_ = self.walk("", func(_ string, t *TLV) error {
+		if t.Tag == tag {
+			result = append(result, t)
+		}
+		return nil
+	})
+	return result
+}
+
+func (d *Doc) FindAll(tag Tag) []*TLV { return d.Props.FindAll(tag) }
+
+// Visits descendants depth first with paths relative to self, see childPaths.
+// Nodes are addressable, callback may edit them. Error other than SkipChildren stops walk.
+func (self *TLV) Walk(fn func(path string, t *TLV) error) error {
+	return self.walkChildren("", fn)
+}
+
+func (d *Doc) Walk(fn func(path string, t *TLV) error) error { return d.Props.Walk(fn) }
+
+func (self *TLV) walk(path string, fn func(string, *TLV) error) error {
+	if err := fn(path, self); err == SkipChildren {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return self.walkChildren(path, fn)
+}
+
+func (self *TLV) walkChildren(path string, fn func(string, *TLV) error) error {
+	prefix := ""
+	if path != "" {
+		prefix = path + "/"
+	}
+	cs := self.Children()
+	paths := childPaths(prefix, cs)
+	for i := range cs {
+		if err := cs[i].walk(paths[i], fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Descendants matching path like "1059/1030" or "1059[2]/1043".
+// Segment without index matches all siblings with tag, index counts siblings with tag from 0.
+func (self *TLV) Query(path string) ([]*TLV, error) {
+	segs, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*TLV{self}
+	for _, seg := range segs {
+		next := make([]*TLV, 0, len(nodes))
+		for _, n := range nodes {
+			cs := n.Children()
+			idx := 0
+			for i := range cs {
+				if cs[i].Tag != seg.tag {
+					continue
+				}
+				if seg.index < 0 || seg.index == idx {
+					next = append(next, &cs[i])
+				}
+				idx++
+			}
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+func (d *Doc) Query(path string) ([]*TLV, error) { return d.Props.Query(path) }
+
+// First node matching path, nil if none or path is invalid.
+func (self *TLV) Get(path string) *TLV {
+	if ts, _ := self.Query(path); len(ts) != 0 {
+		return ts[0]
+	}
+	return nil
+}
+
+func (d *Doc) Get(path string) *TLV { return d.Props.Get(path) }
+
+type pathSegment struct {
+	tag   Tag
+	index int // -1 for all
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is empty")
+	}
+	parts := strings.Split(path, "/")
+	segs := make([]pathSegment, len(parts))
+	for i, part := range parts {
+		seg := pathSegment{index: -1}
+		tagPart := part
+		if open := strings.IndexByte(part, '['); open >= 0 {
+			if !strings.HasSuffix(part, "]") {
+				return nil, fmt.Errorf("path=%q invalid segment=%q", path, part)
+			}
+			n, err := strconv.ParseUint(part[open+1:len(part)-1], 10, 31)
+			if err != nil {
+				return nil, fmt.Errorf("path=%q invalid index in segment=%q", path, part)
+			}
+			seg.index = int(n)
+			tagPart = part[:open]
+		}
+		n, err := strconv.ParseUint(tagPart, 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("path=%q invalid tag in segment=%q", path, part)
+		}
+		seg.tag = Tag(n)
+		segs[i] = seg
+	}
+	return segs, nil
+}
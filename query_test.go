@@ -0,0 +1,130 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQueryDoc(t *testing.T) *Doc {
+	d, err := (&Receipt{
+		Operation: CalcIncome,
+		Items: []Item{
+			{Name: "a", Price: 100, Quantity: NewDecimal(1, 0), VATRate: VAT20},
+			{Name: "b", Price: 200, Quantity: NewDecimal(1, 0), VATRate: VAT10},
+			{Name: "c", Price: 300, Quantity: NewDecimal(1, 0), VATRate: VATNone},
+		},
+	}).Doc()
+	require.NoError(t, err)
+	return d
+}
+
+func TestFindByTagAddressable(t *testing.T) {
+	t.Parallel()
+
+	d := newTestQueryDoc(t)
+	d.FindByTag(1030).SetValue("edited")
+	assert.Equal(t, "edited", d.Props.Children()[1].Children()[0].String())
+	d.Props.FindByTag(1054).SetValue(CalcExpense)
+	assert.Equal(t, CalcExpense, d.Props.Children()[0].CalcSign())
+}
+
+func TestFindAll(t *testing.T) {
+	t.Parallel()
+
+	d := newTestQueryDoc(t)
+	names := d.FindAll(1030)
+	require.Len(t, names, 3)
+	for _, n := range names {
+		n.SetValue(n.String() + "!")
+	}
+	assert.Equal(t, "c!", d.Props.Children()[3].Children()[0].String())
+	assert.Len(t, d.FindAll(1059), 3)
+	assert.Len(t, d.FindAll(1054), 1)
+	assert.Len(t, d.FindAll(1), 0)
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	d := newTestQueryDoc(t)
+	type Case struct {
+		path   string
+		expect []string
+		err    bool
+	}
+	for _, c := range []Case{
+		{"1054", []string{"1"}, false},
+		{"1059/1030", []string{"a", "b", "c"}, false},
+		{"1059[2]/1030", []string{"c"}, false},
+		{"1059[1]/1079", []string{"200"}, false},
+		{"1059[0]", []string{"[(#1030 a) (#1079 100) (#1023 1) (#1199 1)]"}, false},
+		{"1059[3]/1030", []string{}, false},
+		{"1030", []string{}, false},
+		{"", nil, true},
+		{"1059[", nil, true},
+		{"1059[x]/1030", nil, true},
+		{"abc", nil, true},
+		{"1059//1030", nil, true},
+	} {
+		ts, err := d.Query(c.path)
+		if c.err {
+			assert.Error(t, err, c.path)
+			continue
+		}
+		require.NoError(t, err, c.path)
+		ss := make([]string, len(ts))
+		for i, tlv := range ts {
+			if tlv.Kind == DataKindSTLV {
+				ss[i] = tlv.GoString()[len("(#1059 ") : len(tlv.GoString())-1]
+			} else {
+				ss[i] = tlv.String()
+			}
+		}
+		assert.Equal(t, c.expect, ss, c.path)
+	}
+
+	d.Get("1059[1]/1030").SetValue("edited")
+	assert.Equal(t, "edited", d.Props.Children()[2].Children()[0].String())
+	assert.Nil(t, d.Get("1059[5]"))
+	assert.Nil(t, d.Get("bad"))
+	assert.Equal(t, "c", d.Get("1059[2]").Get("1030").String())
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	d := newTestQueryDoc(t)
+	paths := []string{}
+	err := d.Walk(func(path string, tlv *TLV) error {
+		paths = append(paths, path)
+		if path == "1059[1]" {
+			return SkipChildren
+		}
+		if tlv.Tag == 1079 {
+			tlv.SetValue(tlv.Money() * 2)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"1054",
+		"1059[0]", "1059[0]/1030", "1059[0]/1079", "1059[0]/1023", "1059[0]/1199",
+		"1059[1]",
+		"1059[2]", "1059[2]/1030", "1059[2]/1079", "1059[2]/1023", "1059[2]/1199",
+	}, paths)
+	assert.Equal(t, Money(200), d.Get("1059[0]/1079").Money())
+	assert.Equal(t, Money(200), d.Get("1059[1]/1079").Money())
+	assert.Equal(t, Money(600), d.Get("1059[2]/1079").Money())
+
+	stop := fmt.Errorf("stop")
+	n := 0
+	err = d.Walk(func(string, *TLV) error {
+		n++
+		return stop
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 1, n)
+}
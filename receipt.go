@@ -0,0 +1,182 @@
+package ru_nalog
+
+import "fmt"
+
+// Check (FDCheck) with named fields instead of tag numbers.
+// Zero optional fields are not written to document.
+type Receipt struct {
+	Operation       CalcSign  // 1054
+	TaxSystem       TaxSystem // 1055
+	Cashier         string    // 1021
+	CashierINN      string    // 1203
+	CustomerContact string    // 1008, phone or email
+	Items           []Item
+	Payments        []Payment
+}
+
+// Check row, tag 1059.
+type Item struct {
+	Name           string         // 1030
+	Price          Money          // 1079
+	Quantity       Decimal        // 1023
+	VATRate        VATRate        // 1199
+	PaymentMethod  PaymentMethod  // 1214
+	PaymentSubject PaymentSubject // 1212
+}
+
+// Payment type is tag of check total.
+type PaymentType Tag
+
+const (
+	PaymentCash       = PaymentType(TagCashSum)
+	PaymentElectronic = PaymentType(TagElectronicSum)
+	PaymentPrepaid    = PaymentType(TagPrepaidSum)      // зачет аванса
+	PaymentCredit     = PaymentType(TagCreditSum)       // постоплата
+	PaymentOther      = PaymentType(TagOtherPaymentSum) // встречное предоставление
+)
+
+var paymentTypes = []PaymentType{PaymentCash, PaymentElectronic, PaymentPrepaid, PaymentCredit, PaymentOther}
+
+func (p PaymentType) Valid() bool {
+	for _, x := range paymentTypes {
+		if p == x {
+			return true
+		}
+	}
+	return false
+}
+
+type Payment struct {
+	Type PaymentType
+	Sum  Money
+}
+
+// Builds FDCheck document with DefaultTags.
+func (r *Receipt) Doc() (*Doc, error) {
+	d := NewDoc(0, FDCheck)
+	b := receiptBuilder{}
+	b.add(&d.Props, TagCalcSign, r.Operation)
+	b.addNonZero(&d.Props, TagTaxSystem, r.TaxSystem)
+	b.addNonZero(&d.Props, TagCashier, r.Cashier)
+	b.addNonZero(&d.Props, TagCashierINN, r.CashierINN)
+	b.addNonZero(&d.Props, TagCustomerContact, r.CustomerContact)
+	for i := range r.Items {
+		item := &r.Items[i]
+		row := b.add(&d.Props, TagItem, nil)
+		if row == nil {
+			break
+		}
+		b.add(row, TagItemName, item.Name)
+		b.add(row, TagPrice, item.Price)
+		b.add(row, TagQuantity, item.Quantity)
+		b.addNonZero(row, TagVATRate, item.VATRate)
+		b.addNonZero(row, TagPaymentMethod, item.PaymentMethod)
+		b.addNonZero(row, TagPaymentSubject, item.PaymentSubject)
+	}
+	for _, p := range r.Payments {
+		if !p.Type.Valid() {
+			return nil, fmt.Errorf("Receipt.Doc invalid payment type=%d", p.Type)
+		}
+		b.add(&d.Props, Tag(p.Type), p.Sum)
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	return d, nil
+}
+
+// Reverse of Receipt.Doc. Tags not represented in Receipt are ignored.
+func ReceiptFromDoc(d *Doc) (*Receipt, error) {
+	if d == nil || d.Type != FDCheck {
+		return nil, fmt.Errorf("ReceiptFromDoc expected type=%d", FDCheck)
+	}
+	r := &Receipt{}
+	cs := d.Props.Children()
+	for i := range cs {
+		t := &cs[i]
+		if err := t.Err(); err != nil {
+			return nil, err
+		}
+		var err error
+		switch t.Tag {
+		case TagCalcSign:
+			r.Operation, err = t.TryCalcSign()
+		case TagTaxSystem:
+			r.TaxSystem, err = t.TryTaxSystem()
+		case TagCashier:
+			r.Cashier, err = t.TryString()
+		case TagCashierINN:
+			r.CashierINN, err = t.TryString()
+		case TagCustomerContact:
+			r.CustomerContact, err = t.TryString()
+		case TagItem:
+			var item Item
+			item, err = itemFromTLV(t)
+			r.Items = append(r.Items, item)
+		case Tag(PaymentCash), Tag(PaymentElectronic), Tag(PaymentPrepaid), Tag(PaymentCredit), Tag(PaymentOther):
+			p := Payment{Type: PaymentType(t.Tag)}
+			p.Sum, err = t.TryMoney()
+			r.Payments = append(r.Payments, p)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func itemFromTLV(row *TLV) (Item, error) {
+	item := Item{}
+	cs := row.Children()
+	for i := range cs {
+		t := &cs[i]
+		var err error
+		switch t.Tag {
+		case TagItemName:
+			item.Name, err = t.TryString()
+		case TagPrice:
+			item.Price, err = t.TryMoney()
+		case TagQuantity:
+			item.Quantity, err = t.TryDecimal()
+		case TagVATRate:
+			item.VATRate, err = t.TryVATRate()
+		case TagPaymentMethod:
+			item.PaymentMethod, err = t.TryPaymentMethod()
+		case TagPaymentSubject:
+			item.PaymentSubject, err = t.TryPaymentSubject()
+		}
+		if err != nil {
+			return item, err
+		}
+	}
+	return item, nil
+}
+
+// Keeps first error, so Receipt.Doc checks it once.
+type receiptBuilder struct {
+	err error
+}
+
+func (b *receiptBuilder) add(parent *TLV, tag Tag, value interface{}) *TLV {
+	if b.err != nil {
+		return nil
+	}
+	t, err := parent.AppendNewE(tag, value)
+	if err == nil {
+		err = t.checkEnum()
+	}
+	if err != nil {
+		b.err = fmt.Errorf("Receipt.Doc tag=%d: %v", tag, err)
+	}
+	return t
+}
+
+func (b *receiptBuilder) addNonZero(parent *TLV, tag Tag, value interface{}) {
+	if n, ok := toUint64(value); ok && n == 0 {
+		return
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return
+	}
+	b.add(parent, tag, value)
+}
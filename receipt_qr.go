@@ -0,0 +1,234 @@
+package ru_nalog
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/temoto/ru-nalog-go/qr"
+)
+
+// Payload of check QR code, tag 1196:
+// t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1
+type ReceiptQR struct {
+	Time       time.Time // t, device wall clock, minute precision
+	Sum        Money     // s, 1020
+	FN         string    // fn, 1041
+	DocNumber  uint32    // i, 1040
+	FiscalSign uint32    // fp, 1077
+	Operation  CalcSign  // n, 1054
+}
+
+const (
+	qrTimeLayout        = "20060102T1504"
+	qrTimeLayoutSeconds = "20060102T150405"
+)
+
+// Parses 1196 value. Time is returned in UTC location with wall clock of QR.
+func ParseQR(s string) (ReceiptQR, error) {
+	q := ReceiptQR{}
+	values, err := url.ParseQuery(strings.TrimSpace(s))
+	if err != nil {
+		return q, fmt.Errorf("ParseQR s=%q: %v", s, err)
+	}
+	get := func(key string) (string, error) {
+		vs := values[key]
+		if len(vs) != 1 || vs[0] == "" {
+			return "", fmt.Errorf("ParseQR s=%q key=%s missing or repeated", s, key)
+		}
+		return vs[0], nil
+	}
+	var v string
+	if v, err = get("t"); err != nil {
+		return q, err
+	}
+	layout := qrTimeLayout
+	if len(v) == len(qrTimeLayoutSeconds) {
+		layout = qrTimeLayoutSeconds
+	}
+	if q.Time, err = time.Parse(layout, v); err != nil {
+		return q, fmt.Errorf("ParseQR s=%q t: %v", s, err)
+	}
+	if v, err = get("s"); err != nil {
+		return q, err
+	}
+	if q.Sum, err = ParseMoney(v); err != nil {
+		return q, fmt.Errorf("ParseQR s=%q: %v", s, err)
+	}
+	if q.FN, err = get("fn"); err != nil {
+		return q, err
+	}
+	var n uint64
+	parseUint := func(key string, bits int) error {
+		if v, err = get(key); err != nil {
+			return err
+		}
+		if n, err = strconv.ParseUint(v, 10, bits); err != nil {
+			return fmt.Errorf("ParseQR s=%q %s: %v", s, key, err)
+		}
+		return nil
+	}
+	if err = parseUint("i", 32); err != nil {
+		return q, err
+	}
+	q.DocNumber = uint32(n)
+	if err = parseUint("fp", 32); err != nil {
+		return q, err
+	}
+	q.FiscalSign = uint32(n)
+	if err = parseUint("n", 8); err != nil {
+		return q, err
+	}
+	q.Operation = CalcSign(n)
+	if !q.Operation.Valid() {
+		return q, fmt.Errorf("ParseQR s=%q invalid n=%d", s, n)
+	}
+	return q, nil
+}
+
+// Builds payload from document tags 1012, 1020, 1041, 1040 (or Doc.Number), 1077, 1054.
+func NewReceiptQR(d *Doc) (ReceiptQR, error) {
+	q := ReceiptQR{}
+	if d == nil {
+		return q, fmt.Errorf("NewReceiptQR doc=nil")
+	}
+	wrap := func(tag Tag, err error) error { return fmt.Errorf("NewReceiptQR tag=%d: %v", tag, err) }
+	var err error
+	if q.Time, err = findChild(&d.Props, TagDateTime).TryTime(); err != nil {
+		return q, wrap(TagDateTime, err)
+	}
+	if q.Sum, err = findChild(&d.Props, TagTotalSum).TryMoney(); err != nil {
+		return q, wrap(TagTotalSum, err)
+	}
+	if q.FN, err = findChild(&d.Props, TagFNNumber).TryString(); err != nil {
+		return q, wrap(TagFNNumber, err)
+	}
+	q.DocNumber = d.Number
+	if t := findChild(&d.Props, TagDocNumber); t != nil {
+		if q.DocNumber, err = t.TryUint32(); err != nil {
+			return q, wrap(TagDocNumber, err)
+		}
+	}
+	if q.FiscalSign, err = fiscalSign(findChild(&d.Props, TagFiscalSign)); err != nil {
+		return q, wrap(TagFiscalSign, err)
+	}
+	if q.Operation, err = findChild(&d.Props, TagCalcSign).TryCalcSign(); err != nil {
+		return q, wrap(TagCalcSign, err)
+	}
+	return q, nil
+}
+
+func (q ReceiptQR) String() string {
+	return fmt.Sprintf("t=%s&s=%s&fn=%s&i=%d&fp=%d&n=%d",
+		qrTime(q.Time), q.Sum.String(), q.FN, q.DocNumber, q.FiscalSign, q.Operation)
+}
+
+// Wall clock in location of t, like FFD unixtime, see wallClockUnix.
+func qrTime(t time.Time) string { return t.Format(qrTimeLayout) }
+
+// Verifies that QR payload 1196 matches document tags.
+func (d *Doc) CheckQR() error {
+	t := findChild(&d.Props, TagQRCode)
+	if t == nil {
+		return fmt.Errorf("Doc.CheckQR no tag=1196")
+	}
+	s, err := t.TryString()
+	if err != nil {
+		return fmt.Errorf("Doc.CheckQR: %v", err)
+	}
+	got, err := ParseQR(s)
+	if err != nil {
+		return err
+	}
+	expect, err := NewReceiptQR(d)
+	if err != nil {
+		return err
+	}
+	diff := make([]string, 0, 6)
+	check := func(key string, ok bool, e, g interface{}) {
+		if !ok {
+			diff = append(diff, fmt.Sprintf("%s=%v expected=%v", key, g, e))
+		}
+	}
+	et, gt := qrTime(expect.Time), qrTime(got.Time)
+	check("t", et == gt, et, gt)
+	check("s", expect.Sum == got.Sum, expect.Sum, got.Sum)
+	check("fn", expect.FN == got.FN, expect.FN, got.FN)
+	check("i", expect.DocNumber == got.DocNumber, expect.DocNumber, got.DocNumber)
+	check("fp", expect.FiscalSign == got.FiscalSign, expect.FiscalSign, got.FiscalSign)
+	check("n", expect.Operation == got.Operation, uint8(expect.Operation), uint8(got.Operation))
+	if len(diff) != 0 {
+		return fmt.Errorf("Doc.CheckQR mismatch %s", strings.Join(diff, " "))
+	}
+	return nil
+}
+
+// Encodes QR payload 1196 as reported by device, or built by NewReceiptQR if tag is absent.
+// Render with Code.PNG or Code.SVG.
+func (d *Doc) QRCode(level qr.Level) (*qr.Code, error) {
+	payload := ""
+	if t := findChild(&d.Props, TagQRCode); t != nil {
+		s, err := t.TryString()
+		if err != nil {
+			return nil, fmt.Errorf("Doc.QRCode: %v", err)
+		}
+		payload = s
+	} else {
+		q, err := NewReceiptQR(d)
+		if err != nil {
+			return nil, err
+		}
+		payload = q.String()
+	}
+	return qr.Encode(payload, level)
+}
+
+// 1077 is 6 bytes with fiscal sign in last 4 big endian. Devices may report it in print format:
+// decimal digits instead of bytes, or number with registry overriding tag kind.
+// Bytes of only ASCII digits are print format, binary sign of such bytes is misread.
+func fiscalSign(t *TLV) (uint32, error) {
+	if t == nil {
+		return 0, fmt.Errorf("fiscal sign missing")
+	}
+	var s string
+	switch t.Kind {
+	case DataKindBytes:
+		b, err := t.TryBytes()
+		if err != nil {
+			return 0, err
+		}
+		s = string(b)
+		if !isDigits(s) {
+			switch len(b) {
+			case 6:
+				return binary.BigEndian.Uint32(b[2:]), nil
+			case 4:
+				return binary.BigEndian.Uint32(b), nil
+			}
+		}
+	case DataKindString:
+		var err error
+		if s, err = t.TryString(); err != nil {
+			return 0, err
+		}
+	default:
+		return t.TryUint32()
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("fiscal sign print format: %v", err)
+	}
+	return uint32(n), nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,134 @@
+package ru_nalog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temoto/ru-nalog-go/qr"
+)
+
+const testQR = "t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1"
+
+func TestParseQR(t *testing.T) {
+	t.Parallel()
+
+	q, err := ParseQR(testQR)
+	require.NoError(t, err)
+	assert.Equal(t, ReceiptQR{
+		Time:       time.Date(2020, 1, 25, 6, 18, 0, 0, time.UTC),
+		Sum:        200,
+		FN:         "9999078900003063",
+		DocNumber:  8493,
+		FiscalSign: 1765583868,
+		Operation:  CalcIncome,
+	}, q)
+	assert.Equal(t, testQR, q.String())
+
+	q, err = ParseQR("n=2&fp=1&i=2&fn=3&s=100&t=20200125T061859")
+	require.NoError(t, err)
+	assert.Equal(t, 59, q.Time.Second())
+	assert.Equal(t, Money(10000), q.Sum)
+	assert.Equal(t, "t=20200125T0618&s=100.00&fn=3&i=2&fp=1&n=2", q.String())
+
+	for _, s := range []string{
+		"",
+		"t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868",
+		"t=20200125&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=1",
+		"t=20200125T0618&s=2.001&fn=9999078900003063&i=8493&fp=1765583868&n=1",
+		"t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=17655838680&n=1",
+		"t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&fp=1765583868&n=5",
+		"t=20200125T0618&s=2.00&fn=9999078900003063&i=8493&i=8494&fp=1765583868&n=1",
+	} {
+		_, err := ParseQR(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDocCheckQR(t *testing.T) {
+	t.Parallel()
+
+	d := NewDoc(8493, FDCheck)
+	d.AppendNew(1012, time.Date(2020, 1, 25, 6, 18, 19, 0, time.FixedZone("MSK", 3*3600)))
+	d.AppendNew(1020, Money(200))
+	d.AppendNew(1041, "9999078900003063")
+	d.AppendNew(1077, []byte{0, 0, 0x69, 0x3c, 0xab, 0xfc})
+	d.AppendNew(1054, CalcIncome)
+	q, err := NewReceiptQR(d)
+	require.NoError(t, err)
+	assert.Equal(t, testQR, q.String())
+
+	assert.Error(t, d.CheckQR())
+	code, err := d.QRCode(qr.M)
+	require.NoError(t, err)
+	assert.Equal(t, 5, code.Version)
+	qrTag := d.AppendNew(1196, testQR)
+	assert.NoError(t, d.CheckQR())
+	code2, err := d.QRCode(qr.M)
+	require.NoError(t, err)
+	assert.Equal(t, code.SVG(0), code2.SVG(0))
+
+	qrTag.SetValue("t=20200125T0618&s=3.00&fn=9999078900003063&i=8493&fp=1765583868&n=2")
+	err = d.CheckQR()
+	if assert.Error(t, err) {
+		assert.Equal(t, "Doc.CheckQR mismatch s=3.00 expected=2.00 n=2 expected=1", err.Error())
+	}
+
+	// print format of fiscal sign
+	d = NewDoc(8493, FDCheck)
+	d.AppendNew(1077, "1765583868")
+	fp, err := fiscalSign(findChild(&d.Props, 1077))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1765583868), fp)
+	_, err = NewReceiptQR(d)
+	assert.Error(t, err)
+	fp, err = fiscalSign(d.Props.AppendNew(1077, []byte("123456")))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(123456), fp)
+	// binary with some bytes of ASCII digits
+	fp, err = fiscalSign(d.Props.AppendNew(1077, []byte{0x31, 0x04, 0x69, 0x3c, 0x0e, 0x39}))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0x693c0e39), fp)
+	// registry with print format kind
+	printTags := NewTagRegistry(nil, []TagDesc{{Kind: DataKindString, Tag: 1077, Length: 10, Varlen: true}})
+	tlv := printTags.NewTLV(1077)
+	tlv.SetValue("0000012345")
+	fp, err = fiscalSign(tlv)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(12345), fp)
+}
+
+// Not parallel: changes time.Local.
+func TestReceiptQRLocation(t *testing.T) {
+	saved := time.Local
+	defer func() { time.Local = saved }()
+
+	for _, loc := range []*time.Location{time.UTC, time.FixedZone("YEKT", 5*3600), time.FixedZone("NST", -(3*3600 + 1800))} {
+		time.Local = loc
+		d := NewDoc(8493, FDCheck)
+		d.AppendNew(1012, time.Date(2020, 1, 25, 6, 18, 19, 0, time.FixedZone("MSK", 3*3600)))
+		d.AppendNew(1020, Money(200))
+		d.AppendNew(1041, "9999078900003063")
+		d.AppendNew(1040, 8493)
+		d.AppendNew(1077, []byte{0, 0, 0x69, 0x3c, 0xab, 0xfc})
+		d.AppendNew(1054, CalcIncome)
+		d.AppendNew(1196, testQR)
+		require.NoError(t, d.CheckQR(), "loc=%s", loc)
+
+		b, err := d.MarshalBinary()
+		require.NoError(t, err)
+		parsed := &Doc{}
+		require.NoError(t, parsed.UnmarshalBinary(b))
+		assert.Equal(t, time.Date(2020, 1, 25, 6, 18, 19, 0, time.UTC), parsed.Get("1012").Time(), "loc=%s", loc)
+		q, err := NewReceiptQR(parsed)
+		require.NoError(t, err)
+		assert.Equal(t, testQR, q.String(), "loc=%s", loc)
+		assert.NoError(t, parsed.CheckQR(), "loc=%s", loc)
+		assert.Empty(t, Diff(d, parsed), "loc=%s", loc)
+
+		// wall clock of local time
+		d.Get("1012").SetValue(time.Date(2020, 1, 25, 6, 18, 0, 0, time.Local))
+		assert.NoError(t, d.CheckQR(), "loc=%s", loc)
+	}
+}
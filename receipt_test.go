@@ -0,0 +1,49 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReceipt() *Receipt {
+	return &Receipt{
+		Operation:       CalcIncome,
+		TaxSystem:       TaxSimplifiedIncome,
+		Cashier:         "Иванов",
+		CustomerContact: "e@ma.il",
+		Items: []Item{
+			{Name: "item", Price: 700, Quantity: NewDecimal(1500, 3), VATRate: VATNone, PaymentMethod: MethodFullPayment, PaymentSubject: SubjectCommodity},
+			{Name: "other", Price: 100, Quantity: NewDecimal(1, 0), VATRate: VAT20, PaymentMethod: MethodFullPayment},
+		},
+		Payments: []Payment{{Type: PaymentCash, Sum: 150}, {Type: PaymentElectronic, Sum: 1000}},
+	}
+}
+
+func TestReceiptDoc(t *testing.T) {
+	t.Parallel()
+
+	r := newTestReceipt()
+	d, err := r.Doc()
+	require.NoError(t, err)
+	assert.Equal(t, "Doc(#0 Type=3 Props=[(#1054 1) (#1055 2) (#1021 Иванов) (#1008 e@ma.il) "+
+		"(#1059 [(#1030 item) (#1079 700) (#1023 1.500) (#1199 6) (#1214 4) (#1212 1)]) "+
+		"(#1059 [(#1030 other) (#1079 100) (#1023 1) (#1199 1) (#1214 4)]) (#1031 150) (#1081 1000)])", d.String())
+	assert.NoError(t, d.Validate(FFD105))
+
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+	parsed := &Doc{}
+	require.NoError(t, parsed.UnmarshalBinary(b))
+	r2, err := ReceiptFromDoc(parsed)
+	require.NoError(t, err)
+	assert.Equal(t, r, r2)
+
+	_, err = (&Receipt{Cashier: string(make([]byte, 65))}).Doc()
+	assert.Error(t, err)
+	_, err = (&Receipt{Payments: []Payment{{Type: 1043}}}).Doc()
+	assert.Error(t, err)
+	_, err = ReceiptFromDoc(NewDoc(0, FDCycleOpen))
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package ru_nalog
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Set of tag descriptors layered over optional base registry.
+// Lookups are lock-free and safe for concurrent use with Register, which replaces own layer (copy-on-write).
+// Methods on nil *TagRegistry use DefaultTags.
+type TagRegistry struct {
+	base *TagRegistry
+	mu   sync.Mutex   // serializes Register
+	own  atomic.Value // []TagDesc sorted by Tag
+}
+
+var builtinRegistry = NewTagRegistry(nil, builtinTags[:])
+
+// Used by package level FindTag, RegisterTags, NewTLV and when no registry is given explicitly.
+var DefaultTags = NewTagRegistry(builtinRegistry, nil)
+
+// Copies and sorts ts, tags from ts override base.
+func NewTagRegistry(base *TagRegistry, ts []TagDesc) *TagRegistry {
+	r := &TagRegistry{base: base}
+	r.own.Store(sortTagDescs(ts))
+	return r
+}
+
+// New registry with ts on top of r.
+func (r *TagRegistry) Overlay(ts []TagDesc) *TagRegistry {
+	return NewTagRegistry(r.orDefault(), ts)
+}
+
+// Replaces own layer, returns previous value. Concurrent Find sees either old or new layer.
+func (r *TagRegistry) Register(ts []TagDesc) (prev []TagDesc) {
+	r = r.orDefault()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	prev = r.own.Load().([]TagDesc)
+	r.own.Store(sortTagDescs(ts))
+	return prev
+}
+
+// Searches own layer first, then base.
+func (r *TagRegistry) Find(tag Tag) *TagDesc {
+	for r = r.orDefault(); r != nil; r = r.base {
+		if own := r.own.Load().([]TagDesc); len(own) != 0 {
+			if d := searchSortedTagDesc(tag, own); d != nil {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// Searches by Name ignoring case, own layer first, then base.
+// Descriptors overridden in upper layer are not found by their old name.
+func (r *TagRegistry) FindByName(name string) *TagDesc {
+	name = strings.TrimSpace(name)
+	for l := r.orDefault(); l != nil; l = l.base {
+		own := l.own.Load().([]TagDesc)
+		for i := range own {
+			if d := &own[i]; d.Name != "" && strings.EqualFold(d.Name, name) && r.Find(d.Tag) == d {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// Returns nil if tag is not found. Children appended with TLV.AppendNew use the same registry.
+func (r *TagRegistry) NewTLV(tag Tag) *TLV {
+	desc := r.Find(tag)
+	if desc == nil {
+		return nil
+	}
+	tlv := &TLV{TagDesc: *desc, tags: r}
+	if tlv.Kind == DataKindSTLV {
+		tlv.value = make([]TLV, 0, 8)
+	}
+	return tlv
+}
+
+// See ParseTLV.
+func (r *TagRegistry) ParseTLV(b []byte) (*TLV, error) {
+	t := &TLV{tags: r}
+	if err := t.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *TagRegistry) orDefault() *TagRegistry {
+	if r == nil {
+		return DefaultTags
+	}
+	return r
+}
+
+func sortTagDescs(ts []TagDesc) []TagDesc {
+	if len(ts) == 0 {
+		return []TagDesc(nil)
+	}
+	sorted := append([]TagDesc(nil), ts...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Tag < sorted[j].Tag })
+	return sorted
+}
@@ -0,0 +1,99 @@
+package ru_nalog
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRegistry(t *testing.T) {
+	t.Parallel()
+
+	const userTag Tag = 65001
+	base := NewTagRegistry(builtinRegistry, nil)
+	require.Nil(t, base.Find(userTag))
+	require.NotNil(t, base.Find(1054))
+
+	over := base.Overlay([]TagDesc{
+		{Kind: DataKindString, Tag: 1054, Length: 16, Varlen: true},
+		{Kind: DataKindSTLV, Tag: userTag, Length: 64, Varlen: true},
+	})
+	assert.Equal(t, DataKindString, over.Find(1054).Kind)
+	assert.Equal(t, DataKindUint, base.Find(1054).Kind, "overlay must not change base")
+	assert.Nil(t, DefaultTags.Find(userTag))
+
+	// children inherit registry
+	stlv := over.NewTLV(userTag)
+	require.NotNil(t, stlv)
+	child := stlv.AppendNew(1054, "str")
+	require.NoError(t, child.Err())
+	assert.Equal(t, "str", child.String())
+
+	d := NewDoc(1, FDCheck)
+	d.Tags = over
+	assert.NoError(t, d.AppendNew(userTag, nil).Err())
+
+	b, err := d.MarshalBinary()
+	require.NoError(t, err)
+	_, err = ParseTLV(b[4:])
+	assert.Error(t, err, "user tag is not in DefaultTags")
+	parsed, err := over.ParseTLV(b[4:])
+	require.NoError(t, err)
+	assert.Equal(t, userTag, parsed.Tag)
+
+	prev := over.Register(nil)
+	assert.Len(t, prev, 2)
+	assert.Equal(t, DataKindUint, over.Find(1054).Kind)
+}
+
+func TestTagRegistryConcurrent(t *testing.T) {
+	t.Parallel()
+
+	r := NewTagRegistry(builtinRegistry, nil)
+	user := []TagDesc{{Kind: DataKindString, Tag: 65002, Length: 16, Varlen: true}}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if i == 0 {
+					r.Register(user)
+					r.Register(nil)
+				} else if r.Find(1054) == nil {
+					t.Error("builtin tag lost")
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestFindTagByName(t *testing.T) {
+	t.Parallel()
+
+	d := FindTagByName(" Кассир ")
+	require.NotNil(t, d)
+	assert.Equal(t, TagCashier, d.Tag)
+	assert.Equal(t, "кассир", d.Name)
+	assert.Equal(t, "КАССИР", d.PrintCaption)
+	assert.Nil(t, FindTagByName("нет такого"))
+	assert.Nil(t, FindTagByName(""))
+
+	assert.Equal(t, TagCheckVAT20, TagsForVersion(FFD12).FindByName("сумма НДС чека по ставке 20%").Tag)
+	assert.Nil(t, TagsForVersion(FFD105).FindByName("сумма НДС чека по ставке 20%"))
+
+	over := DefaultTags.Overlay([]TagDesc{{Kind: DataKindString, Tag: TagCashier, Length: 64, Varlen: true, Name: "оператор"}})
+	assert.Nil(t, over.FindByName("кассир"), "overridden descriptor")
+	assert.Equal(t, TagCashier, over.FindByName("оператор").Tag)
+	assert.Equal(t, TagCashierINN, over.FindByName("ИНН кассира").Tag)
+
+	for _, table := range [][]TagDesc{tagsFFD10[:], builtinTags[:], tagsFFD11[:], tagsFFD12[:]} {
+		for _, d := range table {
+			assert.NotEmpty(t, d.Name, "tag=%d", d.Tag)
+		}
+	}
+}
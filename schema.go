@@ -0,0 +1,297 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ViolationKind uint8
+
+const (
+	ViolationMissing     ViolationKind = iota + 1 // required tag is absent
+	ViolationUnexpected                           // tag is not allowed in document form or FFD version
+	ViolationWrongParent                          // tag is allowed, but not at this level
+	ViolationDuplicated                           // single tag appears more than once
+	ViolationInvalid                              // enum value is unknown, see EnumError
+)
+
+var violationKindNames = [...]string{
+	ViolationMissing:     "missing",
+	ViolationUnexpected:  "unexpected",
+	ViolationWrongParent: "wrong parent",
+	ViolationDuplicated:  "duplicated",
+	ViolationInvalid:     "invalid",
+}
+
+func (k ViolationKind) String() string {
+	if k == 0 || int(k) >= len(violationKindNames) {
+		return fmt.Sprintf("ViolationKind(%d)", k)
+	}
+	return violationKindNames[k]
+}
+
+type Violation struct {
+	Kind   ViolationKind
+	Tag    Tag
+	Parent Tag    // 0 for document level
+	Path   string // see childPaths
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s", v.Kind.String(), v.Path)
+}
+
+// Document form violations, returned as error by Doc.Validate.
+type Violations []Violation
+
+func (vs Violations) Error() string {
+	ss := make([]string, len(vs))
+	for i, v := range vs {
+		ss[i] = v.String()
+	}
+	return "document form violations: " + strings.Join(ss, ", ")
+}
+
+type presence uint8
+
+const (
+	optional presence = iota
+	required          // must be set before sending to KKT
+	device            // filled by KKT or FN, allowed but not required
+)
+
+// Attribute in document form.
+type formTag struct {
+	Tag      Tag
+	Presence presence
+	Multiple bool
+	Children []formTag // nil: STLV content is not checked
+	// 0 for all versions where tag exists
+	Since         FFDVersion
+	RequiredSince FFDVersion
+}
+
+func formTags(p presence, tags ...Tag) []formTag {
+	ft := make([]formTag, len(tags))
+	for i, t := range tags {
+		ft[i] = formTag{Tag: t, Presence: p}
+	}
+	return ft
+}
+
+func joinForm(parts ...[]formTag) []formTag {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	all := make([]formTag, 0, n)
+	for _, p := range parts {
+		all = append(all, p...)
+	}
+	return all
+}
+
+var (
+	formDeviceCommon = formTags(device, 1009, 1012, 1013, 1018, 1037, 1040, 1041, 1048, 1077, 1187, 1188, 1189, 1209)
+	formCashier      = formTags(optional, 1021, 1203)
+	formAgentData    = formTags(optional, 1005, 1016, 1026, 1044, 1073, 1074, 1075)
+	formSupplierData = formTags(optional, 1171, 1225)
+	formIndustry     = formTags(optional, 1262, 1263, 1264, 1265)
+
+	formItem = joinForm(
+		formTags(required, 1023, 1030, 1079),
+		[]formTag{
+			{Tag: 1199, Presence: required},
+			{Tag: 1212, Presence: required, RequiredSince: FFD11},
+			{Tag: 1214, Presence: required},
+			{Tag: 1223, Children: formAgentData},
+			{Tag: 1224, Children: formSupplierData},
+			{Tag: 1163, Children: formTags(optional, 1300, 1301, 1302, 1303, 1304, 1305, 1306, 1307, 1308, 1309, 1320, 1321, 1322, 1323, 1324, 1325)},
+			{Tag: 1260, Multiple: true, Children: formIndustry},
+			{Tag: 1291, Children: formTags(optional, 1292, 1293, 1294)},
+		},
+		formTags(device, 1043, 1200),
+		formTags(optional, 1162, 1191, 1197, 1198, 1222, 1226, 1229, 1230, 1231, 2000, 2102, 2106, 2108),
+	)
+
+	formBuyer = []formTag{
+		{Tag: 1256, Children: formTags(optional, 1227, 1228, 1243, 1244, 1245, 1246, 1254)},
+		{Tag: 1227}, {Tag: 1228},
+	}
+
+	formCheck = joinForm(
+		formDeviceCommon, formCashier, formAgentData, formSupplierData, formBuyer,
+		formTags(device, 1020, 1031, 1038, 1042, 1060, 1081, 1102, 1103, 1104, 1105, 1106, 1107, 1117, 1196, 1215, 1216, 1217),
+		[]formTag{
+			{Tag: 1054, Presence: required},
+			{Tag: 1059, Presence: required, Multiple: true, Children: formItem},
+			{Tag: 1084, Children: formTags(optional, 1085, 1086)},
+			{Tag: 1261, Multiple: true, Children: formIndustry},
+			{Tag: 1270, Children: formTags(optional, 1271, 1272, 1273)},
+		},
+		formTags(optional, 1008, 1036, 1055, 1057, 1192),
+	)
+
+	formCorrection = joinForm(
+		formDeviceCommon, formCashier, formBuyer,
+		formTags(device, 1038, 1042, 1060, 1117, 1196),
+		formTags(required, 1020, 1031, 1054, 1055, 1081, 1173),
+		[]formTag{
+			{Tag: 1174, Presence: required, Children: joinForm(
+				formTags(optional, 1177),
+				formTags(required, 1178, 1179),
+			)},
+			{Tag: 1059, Multiple: true, Since: FFD12, Children: formItem},
+		},
+		formTags(required, 1215, 1216, 1217),
+		formTags(optional, 1102, 1103, 1104, 1105, 1106, 1107),
+	)
+
+	formRegistration = joinForm(
+		formDeviceCommon, formCashier,
+		formTags(device, 1001, 1002, 1017, 1036, 1046, 1056, 1057, 1060, 1062, 1108, 1109, 1110, 1117, 1126, 1190, 1193, 1207, 1213, 1221),
+	)
+
+	formCycle = joinForm(
+		formDeviceCommon, formCashier,
+		formTags(device, 1038, 1097, 1098, 1111, 1118, 1050, 1051, 1052, 1053, 1190, 1206, 1213),
+		[]formTag{{Tag: 1194, Presence: device}, {Tag: 1157, Presence: device}, {Tag: 1158, Presence: device}},
+	)
+
+	formStateReport = joinForm(
+		formDeviceCommon,
+		formTags(device, 1002, 1038, 1097, 1098, 1116),
+		[]formTag{{Tag: 1157, Presence: device}, {Tag: 1158, Presence: device}},
+	)
+
+	formStorageClose = joinForm(
+		formDeviceCommon, formCashier,
+		formTags(device, 1038),
+		[]formTag{{Tag: 1157, Presence: device}, {Tag: 1158, Presence: device}},
+	)
+
+	formOperatorConfirmation = joinForm(
+		formTags(device, 1012, 1017, 1040, 1041, 1078),
+		[]formTag{{Tag: 1068, Presence: device}},
+	)
+)
+
+var docForms = map[DocType][]formTag{
+	FDRegistration:         formRegistration,
+	FDRegChange:            joinForm(formRegistration, formTags(optional, 1101, 1205)),
+	FDCycleOpen:            formCycle,
+	FDStateReport:          formStateReport,
+	FDCheck:                formCheck,
+	FDCorrectionCheck:      formCorrection,
+	FDBSO:                  formCheck,
+	FDCorrectionBSO:        formCorrection,
+	FDCycleClose:           formCycle,
+	FDStorageClose:         formStorageClose,
+	FDOperatorConfirmation: formOperatorConfirmation,
+}
+
+// Checks document against form tables of given FFD version: mandatory tags, tags unknown
+// in version or form, nesting, duplicates and enum values. Tags filled by KKT are allowed but not required.
+// Returns Violations or nil; plain error for unknown version or document type.
+func (d *Doc) Validate(v FFDVersion) error {
+	tags := TagsForVersion(v)
+	if tags == nil {
+		return fmt.Errorf("Doc.Validate unknown FFD version=%d", v)
+	}
+	form, ok := docForms[d.Type]
+	if !ok {
+		return fmt.Errorf("Doc.Validate unknown document type=%d", d.Type)
+	}
+	val := validator{version: v, tags: tags, everywhere: make(map[Tag]bool, 128)}
+	collectFormTags(form, val.everywhere)
+	val.level(form, d.Props.Children(), 0, "")
+	if len(val.vs) == 0 {
+		return nil
+	}
+	return val.vs
+}
+
+type validator struct {
+	version    FFDVersion
+	tags       *TagRegistry
+	everywhere map[Tag]bool // all tags in form at any level
+	vs         Violations
+}
+
+func (val *validator) level(form []formTag, children []TLV, parent Tag, prefix string) {
+	seen := make(map[Tag]int, len(children))
+	paths := childPaths(prefix, children)
+	for i := range children {
+		child := &children[i]
+		idx := seen[child.Tag]
+		seen[child.Tag]++
+		path := paths[i]
+		ft := findFormTag(form, child.Tag)
+		switch {
+		case val.tags.Find(child.Tag) == nil:
+			val.add(ViolationUnexpected, child.Tag, parent, path)
+		case ft == nil && val.everywhere[child.Tag]:
+			val.add(ViolationWrongParent, child.Tag, parent, path)
+		case ft == nil || val.version < ft.Since:
+			val.add(ViolationUnexpected, child.Tag, parent, path)
+		case idx >= 1 && !ft.Multiple:
+			val.add(ViolationDuplicated, child.Tag, parent, path)
+		case child.checkEnum() != nil:
+			val.add(ViolationInvalid, child.Tag, parent, path)
+		}
+		if ft != nil && ft.Children != nil && child.Kind == DataKindSTLV {
+			val.level(ft.Children, child.Children(), child.Tag, path+"/")
+		}
+	}
+	for i := range form {
+		ft := &form[i]
+		if ft.Presence != required || seen[ft.Tag] != 0 || val.version < ft.Since || val.version < ft.RequiredSince {
+			continue
+		}
+		if val.tags.Find(ft.Tag) == nil {
+			continue
+		}
+		val.add(ViolationMissing, ft.Tag, parent, fmt.Sprintf("%s%d", prefix, ft.Tag))
+	}
+}
+
+func (val *validator) add(kind ViolationKind, tag, parent Tag, path string) {
+	val.vs = append(val.vs, Violation{Kind: kind, Tag: tag, Parent: parent, Path: path})
+}
+
+func findFormTag(form []formTag, tag Tag) *formTag {
+	for i := range form {
+		if form[i].Tag == tag {
+			return &form[i]
+		}
+	}
+	return nil
+}
+
+func collectFormTags(form []formTag, m map[Tag]bool) {
+	for i := range form {
+		m[form[i].Tag] = true
+		collectFormTags(form[i].Children, m)
+	}
+}
+
+// Paths like "1059[1]/1199": tag numbers separated by slash, index counts same tag siblings from 0
+// and is present only when parent has more than one child with this tag.
+func childPaths(prefix string, children []TLV) []string {
+	count := make(map[Tag]int, len(children))
+	for i := range children {
+		count[children[i].Tag]++
+	}
+	seen := make(map[Tag]int, len(count))
+	paths := make([]string, len(children))
+	for i := range children {
+		tag := children[i].Tag
+		if count[tag] == 1 {
+			paths[i] = fmt.Sprintf("%s%d", prefix, tag)
+		} else {
+			paths[i] = fmt.Sprintf("%s%d[%d]", prefix, tag, seen[tag])
+		}
+		seen[tag]++
+	}
+	return paths
+}
@@ -0,0 +1,88 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCheck() *Doc {
+	d := NewDoc(0, FDCheck)
+	d.AppendNew(1054, 1)
+	d.AppendNew(1055, 2)
+	d.AppendNew(1008, "e@ma.il")
+	d.AppendNew(1036, "102030")
+	row := d.AppendNew(1059, nil)
+	row.AppendNew(1023, 1)
+	row.AppendNew(1030, "item")
+	row.AppendNew(1079, 7)
+	row.AppendNew(1199, 6)
+	row.AppendNew(1212, 1)
+	row.AppendNew(1214, 1)
+	return d
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, newTestCheck().Validate(FFD105))
+	require.NoError(t, newTestCheck().Validate(FFD12))
+
+	assert.Error(t, newTestCheck().Validate(0))
+	assert.Error(t, NewDoc(0, 99).Validate(FFD105))
+
+	d := newTestCheck()
+	d.Tags = TagsForVersion(FFD12)
+	d.AppendNew(1054, 2)
+	d.AppendNew(1030, "misplaced")
+	d.AppendNew(1256, nil)
+	row := d.AppendNew(1059, nil)
+	row.AppendNew(1030, "second")
+	row.AppendNew(1079, 7)
+	row.AppendNew(1023, 1)
+	row.AppendNew(1214, 4)
+	row.AppendNew(1040, 1)
+	err := d.Validate(FFD11)
+	require.Error(t, err)
+	vs, ok := err.(Violations)
+	require.True(t, ok, "err=%v", err)
+	assert.Equal(t, Violations{
+		{Kind: ViolationDuplicated, Tag: 1054, Path: "1054[1]"},
+		{Kind: ViolationWrongParent, Tag: 1030, Path: "1030"},
+		{Kind: ViolationUnexpected, Tag: 1256, Path: "1256"},
+		{Kind: ViolationWrongParent, Tag: 1040, Parent: 1059, Path: "1059[1]/1040"},
+		{Kind: ViolationMissing, Tag: 1199, Parent: 1059, Path: "1059[1]/1199"},
+		{Kind: ViolationMissing, Tag: 1212, Parent: 1059, Path: "1059[1]/1212"},
+	}, vs)
+	assert.Contains(t, vs.Error(), "missing 1059[1]/1199")
+
+	// every extra occurrence is reported
+	d = newTestCheck()
+	d.AppendNew(1055, 1)
+	d.AppendNew(1055, 4)
+	assert.Equal(t, Violations{
+		{Kind: ViolationDuplicated, Tag: 1055, Path: "1055[1]"},
+		{Kind: ViolationDuplicated, Tag: 1055, Path: "1055[2]"},
+	}, d.Validate(FFD105))
+}
+
+func TestValidateCorrection(t *testing.T) {
+	t.Parallel()
+
+	d := NewDoc(0, FDCorrectionCheck)
+	d.AppendNew(1054, 1)
+	d.AppendNew(1055, 1)
+	d.AppendNew(1173, false)
+	for _, tag := range []Tag{1020, 1031, 1081, 1215, 1216, 1217} {
+		d.AppendNew(tag, 0)
+	}
+	basis := d.AppendNew(1174, nil)
+	basis.AppendNew(1178, 1579922299)
+	err := d.Validate(FFD105)
+	require.Error(t, err)
+	assert.Equal(t, Violations{{Kind: ViolationMissing, Tag: 1179, Parent: 1174, Path: "1174/1179"}}, err)
+
+	basis.AppendNew(1179, "1")
+	assert.NoError(t, d.Validate(FFD105))
+}
@@ -0,0 +1,131 @@
+# coding: utf-8
+"""Per FFD version differences applied on top of the official tags table.
+
+Official tags table (script/generate, приказ ФНС от 21.03.2017 № ММВ-7-20/229@)
+describes all attributes, but not every attribute exists in every FFD version.
+Each version lists:
+- edition: official document which defines this version, patch lists follow it
+- remove: tags absent in this version
+- add: (kind, tag, length, varlen, name) attributes missing from the table
+  (print-only attributes, newer versions)
+- rename: tag -> new name
+
+`generate-tags.py --check` compares add and rename with tags table of the edition.
+Remove is not checked: tags table of an edition lists attributes of all its versions.
+"""
+
+# print-only attributes which devices still report in electronic form
+COMMON_ADD = [
+    ("DataKindString", 1196, 256, True, "QR-код"),
+]
+
+FFD105_ONLY = [
+    1162, 1191, 1192, 1197, 1198, 1199, 1200, 1205, 1212, 1214,
+    1215, 1216, 1217, 1218, 1219, 1220, 1221, 1222, 1223, 1224, 1225, 1226,
+]
+
+FFD11_ADD = [
+    ("DataKindString", 1227, 256, True, "покупатель (клиент)"),
+    ("DataKindString", 1228, 12, False, "ИНН покупателя (клиента)"),
+    ("DataKindVLN", 1229, 6, True, "акциз"),
+    ("DataKindString", 1230, 3, False, "код страны происхождения товара"),
+    ("DataKindString", 1231, 32, True, "номер декларации на товар"),
+]
+
+FFD12_ADD = [
+    ("DataKindSTLV", 1163, 1024, True, "код товара"),
+    ("DataKindSTLV", 1256, 1024, True, "сведения о покупателе (клиенте)"),
+    ("DataKindString", 1243, 10, False, "дата рождения покупателя (клиента)"),
+    ("DataKindString", 1244, 3, False, "гражданство"),
+    ("DataKindString", 1245, 2, False, "код вида документа, удостоверяющего личность"),
+    ("DataKindString", 1246, 64, True, "данные документа, удостоверяющего личность"),
+    ("DataKindString", 1254, 256, True, "адрес покупателя (клиента)"),
+    ("DataKindSTLV", 1260, 384, True, "отраслевой реквизит предмета расчета"),
+    ("DataKindSTLV", 1261, 384, True, "отраслевой реквизит чека"),
+    ("DataKindString", 1262, 3, False, "идентификатор ФОИВ"),
+    ("DataKindString", 1263, 10, False, "дата документа основания"),
+    ("DataKindString", 1264, 32, True, "номер документа основания"),
+    ("DataKindString", 1265, 256, True, "значение отраслевого реквизита"),
+    ("DataKindSTLV", 1270, 144, True, "операционный реквизит чека"),
+    ("DataKindUint", 1271, 1, False, "идентификатор операции"),
+    ("DataKindString", 1272, 64, True, "данные операции"),
+    ("DataKindTime", 1273, 4, False, "дата, время операции"),
+    ("DataKindSTLV", 1291, 48, True, "дробное количество маркированного товара"),
+    ("DataKindString", 1292, 24, True, "дробная часть"),
+    ("DataKindVLN", 1293, 8, True, "числитель"),
+    ("DataKindVLN", 1294, 8, True, "знаменатель"),
+    ("DataKindString", 1300, 32, True, "КТ Н"),
+    ("DataKindString", 1301, 8, False, "КТ EAN-8"),
+    ("DataKindString", 1302, 13, False, "КТ EAN-13"),
+    ("DataKindString", 1303, 14, False, "КТ ITF-14"),
+    ("DataKindString", 1304, 38, True, "КТ GS1.0"),
+    ("DataKindString", 1305, 200, True, "КТ GS1.М"),
+    ("DataKindString", 1306, 38, True, "КТ КМК"),
+    ("DataKindString", 1307, 20, True, "КТ МИ"),
+    ("DataKindString", 1308, 33, True, "КТ ЕГАИС-2.0"),
+    ("DataKindString", 1309, 14, True, "КТ ЕГАИС-3.0"),
+    ("DataKindString", 1320, 32, True, "КТ Ф.1"),
+    ("DataKindString", 1321, 32, True, "КТ Ф.2"),
+    ("DataKindString", 1322, 32, True, "КТ Ф.3"),
+    ("DataKindString", 1323, 32, True, "КТ Ф.4"),
+    ("DataKindString", 1324, 32, True, "КТ Ф.5"),
+    ("DataKindString", 1325, 32, True, "КТ Ф.6"),
+    ("DataKindString", 2000, 256, True, "код маркировки"),
+    ("DataKindUint", 2003, 1, False, "планируемый статус товара"),
+    ("DataKindUint", 2004, 1, False, "результат проверки КМ"),
+    ("DataKindUint", 2005, 1, False, "результаты обработки запроса"),
+    ("DataKindUint", 2100, 1, False, "тип кода маркировки"),
+    ("DataKindString", 2101, 255, True, "идентификатор товара"),
+    ("DataKindUint", 2102, 1, False, "режим обработки кода маркировки"),
+    ("DataKindUint", 2104, 4, False, "количество непереданных уведомлений"),
+    ("DataKindUint", 2105, 1, False, "коды обработки запроса"),
+    ("DataKindUint", 2106, 1, False, "результат проверки сведений о товаре"),
+    ("DataKindUint", 2107, 1, False, "результаты проверки маркированных товаров"),
+    ("DataKindUint", 2108, 1, False, "мера количества предмета расчета"),
+    ("DataKindUint", 2109, 1, False, "ответ ОИСМ о статусе товара"),
+    ("DataKindUint", 2110, 1, False, "присвоенный статус товара"),
+    ("DataKindUint", 2111, 1, False, "коды обработки уведомления"),
+    ("DataKindUint", 2112, 1, False, "признак некорректных кодов маркировки"),
+    ("DataKindUint", 2113, 1, False, "признак некорректных запросов и уведомлений"),
+    ("DataKindTime", 2114, 4, False, "дата и время запроса"),
+    ("DataKindString", 2115, 4, False, "контрольный код КМ"),
+]
+
+FFD12_RENAME = {
+    1102: "сумма НДС чека по ставке 20%",
+    1106: "сумма НДС чека по расч. ставке 20/120",
+    1139: "сумма НДС по ставке 20%",
+    1141: "сумма НДС по расч. ставке 20/120",
+    1151: "сумма коррекций НДС по ставке 20%",
+    1153: "сумма коррекций НДС по расч. ставке 20/120",
+}
+
+EDITION_2017 = "приказ ФНС от 21.03.2017 № ММВ-7-20/229@"
+EDITION_2020 = "приказ ФНС от 14.09.2020 № ЕД-7-20/662@"
+
+VERSIONS = {
+    "1.0": {
+        "edition": EDITION_2017,
+        "add": COMMON_ADD,
+        "remove": FFD105_ONLY,
+        "rename": {},
+    },
+    "1.05": {
+        "edition": EDITION_2017,
+        "add": COMMON_ADD,
+        "remove": [],
+        "rename": {},
+    },
+    "1.1": {
+        "edition": EDITION_2020,
+        "add": COMMON_ADD + FFD11_ADD,
+        "remove": [],
+        "rename": {},
+    },
+    "1.2": {
+        "edition": EDITION_2020,
+        "add": COMMON_ADD + FFD11_ADD + FFD12_ADD,
+        "remove": [1162],
+        "rename": FFD12_RENAME,
+    },
+}
@@ -1,11 +1,16 @@
 #!/usr/bin/env python3
 # coding: utf-8
 """Generate Go code for ru_nalog tag descriptors from official document.
-Needs env GOPACKAGE provided by `go generate.
+Needs env GOPACKAGE provided by script/generate.
+Version differences are applied from ffd_versions.py.
+Output is compared with tables maintained by hand, header is the same to keep diff short.
 """
-import os, sys
+import argparse, json, os, sys
 import docx.api
 
+from ffd_versions import VERSIONS
+from tag_names import GO_NAMES
+
 
 TAGS_TABLE_HEADER = ("Тег", "Наименование реквизита", "Тип", "Формат ЭФ",
     "Формат ПФ", "Фикс.", "Длина", "Примечание")
@@ -28,13 +33,23 @@ KINDMAP = {
 }
 
 OUTPUT_HEADER = """\
-// WARNING: This file has automatically been generated
-// Code generated by generate-tags.py DO NOT EDIT.
+// Maintained by hand, compare with output of script/generate: names follow the official table,
+// print captions follow printable form of devices, notes are not filled yet.
 
 package {package}
 
-var builtinTags = [...]TagDesc{{
-""".format(package=os.environ["GOPACKAGE"])
+// FFD {version}
+var {var} = [...]TagDesc{{
+"""
+
+CONSTS_HEADER = """\
+// Maintained by hand, compare with output of script/generate.
+
+package {package}
+
+// Tags of all FFD versions, comment is name in the latest version.
+const (
+"""
 
 
 def log(*a, **kw):
@@ -59,7 +74,8 @@ def extract_data(table):
     return data
 
 
-def format_output_line(row, max_length=None):
+def parse_row(row):
+    """Returns (kind, tag, length, varlen, name, print_caption, note) or None for print-only attributes."""
     tag = row[0]
     name = row[1].strip()
     type_ = row[2].strip().lower()
@@ -67,41 +83,122 @@ def format_output_line(row, max_length=None):
     fmt_printed = row[4].strip().lower()
     len_variable = row[5].strip().lower() == "нет"
     length = row[6].strip().lower()
-    note = row[7].strip().lower()
+    note = " ".join(row[7].split())
+    print_caption = " ".join(row[4].split())
+    if print_caption == CELL_DASH:
+        print_caption = ""
 
     if tag == CELL_DASH or fmt_digital == CELL_DASH:
-        return ""
+        return None
 
-    var_token = "true" if len_variable else "false"
     kind = KINDMAP.get(fmt_digital)
-    if BOOL_SUBSTRING in note:
+    if BOOL_SUBSTRING in note.lower():
         kind = "DataKindBool"
     if kind is None:
-        raise Exception("unknown kind='{}' row={}".format(kindspec, row))
-    line = "\t{{{kind}, {tag}, {len}, {var}}},".format(
-        kind=kind, tag=tag, len=length, var=var_token,
+        raise Exception("unknown kind='{}' row={}".format(fmt_digital, row))
+    return (kind, int(tag), int(length), len_variable, name, print_caption, note)
+
+
+def apply_version(descs, version):
+    patch = VERSIONS[version]
+    remove = set(patch["remove"])
+    by_tag = {d[1]: d for d in descs if d[1] not in remove}
+    for d in patch["add"]:
+        # added attributes may omit print caption and note
+        by_tag[d[1]] = tuple(d) + ("",) * (7 - len(d))
+    for tag, name in patch["rename"].items():
+        by_tag[tag] = by_tag[tag][:4] + (name,) + by_tag[tag][5:]
+    return [by_tag[tag] for tag in sorted(by_tag)]
+
+
+def check_version(version, data):
+    """Returns disagreements of version patch with tags table of its edition."""
+    patch = VERSIONS[version]
+    edition = {}
+    for row in data:
+        if row[0].strip().isdigit():
+            edition[int(row[0])] = parse_row(row)
+    errors = []
+    for d in patch["add"]:
+        tag = d[1]
+        if tag not in edition:
+            errors.append("add {}: absent".format(tag))
+        elif edition[tag] is not None and edition[tag][:4] != tuple(d[:4]):
+            errors.append("add {}: {} in edition".format(d[:4], edition[tag][:4]))
+    for tag, name in patch["rename"].items():
+        if tag not in edition or edition[tag] is None:
+            errors.append("rename {}: absent".format(tag))
+        elif edition[tag][4] != name:
+            errors.append("rename {}: {!r} in edition".format(tag, edition[tag][4]))
+    return errors
+
+
+def go_string(s):
+    return json.dumps(s, ensure_ascii=False)
+
+
+def format_output_line(desc):
+    kind, tag, length, varlen, name, print_caption, note = desc
+    return "\t{{{kind}, {tag}, {len}, {var}, {name}, {caption}, {note}}},".format(
+        kind=kind, tag=tag, len=length, var="true" if varlen else "false",
+        name=go_string(name), caption=go_string(print_caption), note=go_string(note),
     )
-    if max_length is not None:
-        padding = " " * (max_length - len(line))
-        return "{}{} // {}".format(line, padding, name)
-    return line
 
 
-def main():
-    # TODO argparse
-    if len(sys.argv) < 2:
-        log("usage: {} filename.docx".format(sys.argv[0]))
-        sys.exit(1)
-    filename = sys.argv[1]
+def format_consts(descs):
+    """descs of all versions, later override earlier."""
+    by_tag = {}
+    for d in descs:
+        by_tag[d[1]] = d
+    missing = sorted(set(by_tag) - set(GO_NAMES))
+    if missing:
+        raise Exception("no Go name in tag_names.py for tags {}".format(missing))
+    tags = sorted(by_tag)
+    names = ["Tag" + GO_NAMES[tag] for tag in tags]
+    values = ["Tag = {}".format(tag) for tag in tags]
+    name_width = max(len(n) for n in names)
+    value_width = max(len(v) for v in values)
+    return "".join("\t{} {} // {}\n".format(n.ljust(name_width), v.ljust(value_width), by_tag[tag][4])
+        for n, v, tag in zip(names, values, tags))
+
 
-    doc = docx.api.Document(filename)
+def main():
+    parser = argparse.ArgumentParser()
+    parser.add_argument("--version", choices=sorted(VERSIONS))
+    parser.add_argument("--var", help="Go variable name")
+    parser.add_argument("--consts", action="store_true", help="Tag constants of all versions")
+    parser.add_argument("--check", action="store_true",
+        help="check --version patch against filename, official edition of this version")
+    parser.add_argument("filename", help="official .docx")
+    args = parser.parse_args()
+
+    doc = docx.api.Document(args.filename)
     table = select_tags_table(doc)
     data = extract_data(table)
-
-    max_length = max(len(format_output_line(r)) for r in data)
-    body = "".join(line + "\n" for line in (format_output_line(r, max_length=max_length) for r in data) if line)
-
-    print(OUTPUT_HEADER + body + "\n}")
+    if args.check:
+        if not args.version:
+            parser.error("--version is required")
+        errors = check_version(args.version, data)
+        for e in errors:
+            log("FFD {} ({}): {}".format(args.version, VERSIONS[args.version]["edition"], e))
+        sys.exit(1 if errors else 0)
+    official = [d for d in (parse_row(r) for r in data) if d]
+    package = os.environ["GOPACKAGE"]
+
+    if args.consts:
+        descs = []
+        for version in sorted(VERSIONS, key=lambda v: tuple(int(x) for x in v.split("."))):
+            descs.extend(apply_version(official, version))
+        print(CONSTS_HEADER.format(package=package) + format_consts(descs) + ")")
+        return
+    if not args.version or not args.var:
+        parser.error("--version and --var are required")
+
+    descs = apply_version(official, args.version)
+    body = "".join(format_output_line(d) + "\n" for d in descs)
+
+    header = OUTPUT_HEADER.format(package=package, version=args.version, var=args.var)
+    print(header + body + "}")
 
 
 if __name__ == "__main__":
@@ -0,0 +1,202 @@
+# coding: utf-8
+"""Go constant names of tags, generated as Tag<Name>. Covers tags of all FFD versions."""
+
+GO_NAMES = {
+    1001: "AutomaticMode",
+    1002: "OfflineMode",
+    1005: "TransferOperatorAddress",
+    1008: "CustomerContact",
+    1009: "PaymentAddress",
+    1012: "DateTime",
+    1013: "KKTSerial",
+    1016: "TransferOperatorINN",
+    1017: "OFDINN",
+    1018: "UserINN",
+    1020: "TotalSum",
+    1021: "Cashier",
+    1022: "OFDResponseCode",
+    1023: "Quantity",
+    1026: "TransferOperatorName",
+    1030: "ItemName",
+    1031: "CashSum",
+    1036: "AutomatNumber",
+    1037: "KKTRegNumber",
+    1038: "CycleNumber",
+    1040: "DocNumber",
+    1041: "FNNumber",
+    1042: "CheckNumber",
+    1043: "ItemSum",
+    1044: "PayingAgentOperation",
+    1046: "OFDName",
+    1048: "UserName",
+    1050: "FNResourceExhausted",
+    1051: "FNReplaceUrgent",
+    1052: "FNMemoryOverflow",
+    1053: "OFDTimeout",
+    1054: "CalcSign",
+    1055: "TaxSystem",
+    1056: "Encryption",
+    1057: "AgentFlags",
+    1059: "Item",
+    1060: "FNSSite",
+    1062: "TaxSystems",
+    1068: "OperatorMessageForFN",
+    1073: "PayingAgentPhone",
+    1074: "PaymentOperatorPhone",
+    1075: "TransferOperatorPhone",
+    1077: "FiscalSign",
+    1078: "OperatorFiscalSign",
+    1079: "Price",
+    1081: "ElectronicSum",
+    1084: "UserProp",
+    1085: "UserPropName",
+    1086: "UserPropValue",
+    1097: "UnsentDocCount",
+    1098: "FirstUnsentDocTime",
+    1101: "ReregReason",
+    1102: "CheckVAT20",
+    1103: "CheckVAT10",
+    1104: "CheckSumVAT0",
+    1105: "CheckSumNoVAT",
+    1106: "CheckVAT20120",
+    1107: "CheckVAT10110",
+    1108: "InternetOnly",
+    1109: "Services",
+    1110: "BSOMode",
+    1111: "CycleDocCount",
+    1116: "FirstUnsentDocNumber",
+    1117: "SenderEmail",
+    1118: "CycleCheckCount",
+    1126: "Lottery",
+    1129: "IncomeCounters",
+    1130: "IncomeReturnCounters",
+    1131: "ExpenseCounters",
+    1132: "ExpenseReturnCounters",
+    1133: "CorrectionCounters",
+    1134: "CheckCountAll",
+    1135: "CheckCount",
+    1136: "CashTotal",
+    1138: "ElectronicTotal",
+    1139: "VAT20Total",
+    1140: "VAT10Total",
+    1141: "VAT20120Total",
+    1142: "VAT10110Total",
+    1143: "SumVAT0Total",
+    1144: "CorrectionCheckCount",
+    1145: "IncomeCorrectionCounters",
+    1146: "ExpenseCorrectionCounters",
+    1148: "SelfCorrectionCount",
+    1149: "OrderedCorrectionCount",
+    1151: "CorrectionVAT20Total",
+    1152: "CorrectionVAT10Total",
+    1153: "CorrectionVAT20120Total",
+    1154: "CorrectionVAT10110Total",
+    1155: "CorrectionSumVAT0Total",
+    1157: "FNCounters",
+    1158: "UnsentDocCounters",
+    1162: "NomenclatureCode",
+    1163: "ProductCode",
+    1171: "SupplierPhone",
+    1173: "CorrectionType",
+    1174: "CorrectionBasis",
+    1177: "CorrectionDescription",
+    1178: "CorrectionBasisDate",
+    1179: "CorrectionBasisNumber",
+    1183: "SumNoVATTotal",
+    1184: "CorrectionSumNoVATTotal",
+    1187: "PaymentPlace",
+    1188: "KKTVersion",
+    1189: "KKTFFDVersion",
+    1190: "FNFFDVersion",
+    1191: "ItemExtraProp",
+    1192: "CheckExtraProp",
+    1193: "Gambling",
+    1194: "CycleCounters",
+    1196: "QRCode",
+    1197: "Unit",
+    1198: "UnitVAT",
+    1199: "VATRate",
+    1200: "ItemVAT",
+    1201: "ChecksTotal",
+    1203: "CashierINN",
+    1205: "ReregReasons",
+    1206: "OperatorMessage",
+    1207: "ExciseTrade",
+    1208: "ChecksSite",
+    1209: "FFDVersion",
+    1212: "PaymentSubject",
+    1213: "FNKeysResource",
+    1214: "PaymentMethod",
+    1215: "PrepaidSum",
+    1216: "CreditSum",
+    1217: "OtherPaymentSum",
+    1218: "PrepaidTotal",
+    1219: "CreditTotal",
+    1220: "OtherPaymentTotal",
+    1221: "ExternPrinter",
+    1222: "ItemAgentFlags",
+    1223: "AgentData",
+    1224: "SupplierData",
+    1225: "SupplierName",
+    1226: "SupplierINN",
+    1227: "Customer",
+    1228: "CustomerINN",
+    1229: "Excise",
+    1230: "OriginCountry",
+    1231: "CustomsDeclaration",
+    1243: "CustomerBirthDate",
+    1244: "Citizenship",
+    1245: "IdentityDocCode",
+    1246: "IdentityDocData",
+    1254: "CustomerAddress",
+    1256: "CustomerInfo",
+    1260: "ItemIndustryProp",
+    1261: "CheckIndustryProp",
+    1262: "FOIVID",
+    1263: "BasisDocDate",
+    1264: "BasisDocNumber",
+    1265: "IndustryPropValue",
+    1270: "CheckOperationalProp",
+    1271: "OperationID",
+    1272: "OperationData",
+    1273: "OperationTime",
+    1291: "FractionalQuantity",
+    1292: "FractionalPart",
+    1293: "Numerator",
+    1294: "Denominator",
+    1300: "ProductCodeUnknown",
+    1301: "ProductCodeEAN8",
+    1302: "ProductCodeEAN13",
+    1303: "ProductCodeITF14",
+    1304: "ProductCodeGS10",
+    1305: "ProductCodeGS1M",
+    1306: "ProductCodeKMK",
+    1307: "ProductCodeMI",
+    1308: "ProductCodeEGAIS20",
+    1309: "ProductCodeEGAIS30",
+    1320: "ProductCodeF1",
+    1321: "ProductCodeF2",
+    1322: "ProductCodeF3",
+    1323: "ProductCodeF4",
+    1324: "ProductCodeF5",
+    1325: "ProductCodeF6",
+    2000: "MarkCode",
+    2003: "PlannedItemStatus",
+    2004: "MarkCheckResult",
+    2005: "RequestResults",
+    2100: "MarkCodeType",
+    2101: "ProductID",
+    2102: "MarkProcessingMode",
+    2104: "UnsentNotificationCount",
+    2105: "RequestProcessingCodes",
+    2106: "ItemCheckResult",
+    2107: "MarkedItemsCheckResults",
+    2108: "QuantityMeasure",
+    2109: "OISMItemStatus",
+    2110: "AssignedItemStatus",
+    2111: "NotificationProcessingCodes",
+    2112: "InvalidMarkCodes",
+    2113: "InvalidRequests",
+    2114: "RequestTime",
+    2115: "MarkControlCode",
+}
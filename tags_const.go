@@ -0,0 +1,204 @@
+// Maintained by hand, compare with output of script/generate.
+
+package ru_nalog
+
+// Tags of all FFD versions, comment is name in the latest version.
+const (
+	TagAutomaticMode               Tag = 1001 // признак автоматического режима
+	TagOfflineMode                 Tag = 1002 // признак автономного режима
+	TagTransferOperatorAddress     Tag = 1005 // адрес оператора перевода
+	TagCustomerContact             Tag = 1008 // телефон или электронный адрес покупателя
+	TagPaymentAddress              Tag = 1009 // адрес расчетов
+	TagDateTime                    Tag = 1012 // дата, время
+	TagKKTSerial                   Tag = 1013 // заводской номер ККТ
+	TagTransferOperatorINN         Tag = 1016 // ИНН оператора перевода
+	TagOFDINN                      Tag = 1017 // ИНН ОФД
+	TagUserINN                     Tag = 1018 // ИНН пользователя
+	TagTotalSum                    Tag = 1020 // сумма расчета, указанного в чеке (БСО)
+	TagCashier                     Tag = 1021 // кассир
+	TagOFDResponseCode             Tag = 1022 // код ответа ОФД
+	TagQuantity                    Tag = 1023 // количество предмета расчета
+	TagTransferOperatorName        Tag = 1026 // наименование оператора перевода
+	TagItemName                    Tag = 1030 // наименование предмета расчета
+	TagCashSum                     Tag = 1031 // сумма по чеку (БСО) наличными
+	TagAutomatNumber               Tag = 1036 // номер автомата
+	TagKKTRegNumber                Tag = 1037 // регистрационный номер ККТ
+	TagCycleNumber                 Tag = 1038 // номер смены
+	TagDocNumber                   Tag = 1040 // номер ФД
+	TagFNNumber                    Tag = 1041 // номер ФН
+	TagCheckNumber                 Tag = 1042 // номер чека за смену
+	TagItemSum                     Tag = 1043 // стоимость предмета расчета с учетом скидок и наценок
+	TagPayingAgentOperation        Tag = 1044 // операция платежного агента
+	TagOFDName                     Tag = 1046 // наименование ОФД
+	TagUserName                    Tag = 1048 // наименование пользователя
+	TagFNResourceExhausted         Tag = 1050 // признак исчерпания ресурса ФН
+	TagFNReplaceUrgent             Tag = 1051 // признак необходимости срочной замены ФН
+	TagFNMemoryOverflow            Tag = 1052 // признак переполнения памяти ФН
+	TagOFDTimeout                  Tag = 1053 // признак превышения времени ожидания ответа ОФД
+	TagCalcSign                    Tag = 1054 // признак расчета
+	TagTaxSystem                   Tag = 1055 // применяемая система налогообложения
+	TagEncryption                  Tag = 1056 // признак шифрования
+	TagAgentFlags                  Tag = 1057 // признак агента
+	TagItem                        Tag = 1059 // предмет расчета
+	TagFNSSite                     Tag = 1060 // адрес сайта ФНС
+	TagTaxSystems                  Tag = 1062 // системы налогообложения
+	TagOperatorMessageForFN        Tag = 1068 // сообщение оператора для ФН
+	TagPayingAgentPhone            Tag = 1073 // телефон платежного агента
+	TagPaymentOperatorPhone        Tag = 1074 // телефон оператора по приему платежей
+	TagTransferOperatorPhone       Tag = 1075 // телефон оператора перевода
+	TagFiscalSign                  Tag = 1077 // ФПД
+	TagOperatorFiscalSign          Tag = 1078 // ФПО
+	TagPrice                       Tag = 1079 // цена за единицу предмета расчета с учетом скидок и наценок
+	TagElectronicSum               Tag = 1081 // сумма по чеку (БСО) электронными
+	TagUserProp                    Tag = 1084 // дополнительный реквизит пользователя
+	TagUserPropName                Tag = 1085 // наименование дополнительного реквизита пользователя
+	TagUserPropValue               Tag = 1086 // значение дополнительного реквизита пользователя
+	TagUnsentDocCount              Tag = 1097 // количество непереданных ФД
+	TagFirstUnsentDocTime          Tag = 1098 // дата и время первого из непереданных ФД
+	TagReregReason                 Tag = 1101 // код причины перерегистрации
+	TagCheckVAT20                  Tag = 1102 // сумма НДС чека по ставке 20%
+	TagCheckVAT10                  Tag = 1103 // сумма НДС чека по ставке 10%
+	TagCheckSumVAT0                Tag = 1104 // сумма расчета по чеку с НДС по ставке 0%
+	TagCheckSumNoVAT               Tag = 1105 // сумма расчета по чеку без НДС
+	TagCheckVAT20120               Tag = 1106 // сумма НДС чека по расч. ставке 20/120
+	TagCheckVAT10110               Tag = 1107 // сумма НДС чека по расч. ставке 10/110
+	TagInternetOnly                Tag = 1108 // признак ККТ для расчетов только в Интернет
+	TagServices                    Tag = 1109 // признак расчетов за услуги
+	TagBSOMode                     Tag = 1110 // признак АС БСО
+	TagCycleDocCount               Tag = 1111 // общее количество ФД за смену
+	TagFirstUnsentDocNumber        Tag = 1116 // номер первого непереданного документа
+	TagSenderEmail                 Tag = 1117 // адрес электронной почты отправителя чека
+	TagCycleCheckCount             Tag = 1118 // количество кассовых чеков (БСО) за смену
+	TagLottery                     Tag = 1126 // признак проведения лотереи
+	TagIncomeCounters              Tag = 1129 // счетчики операций «приход»
+	TagIncomeReturnCounters        Tag = 1130 // счетчики операций «возврат прихода»
+	TagExpenseCounters             Tag = 1131 // счетчики операций «расход»
+	TagExpenseReturnCounters       Tag = 1132 // счетчики операций «возврат расхода»
+	TagCorrectionCounters          Tag = 1133 // счетчики операций по чекам коррекции
+	TagCheckCountAll               Tag = 1134 // количество чеков (БСО) со всеми признаками расчетов
+	TagCheckCount                  Tag = 1135 // количество чеков по признаку расчетов
+	TagCashTotal                   Tag = 1136 // итоговая сумма в чеках (БСО) наличными денежными средствами
+	TagElectronicTotal             Tag = 1138 // итоговая сумма в чеках (БСО) электронными средствами платежа
+	TagVAT20Total                  Tag = 1139 // сумма НДС по ставке 20%
+	TagVAT10Total                  Tag = 1140 // сумма НДС по ставке 10%
+	TagVAT20120Total               Tag = 1141 // сумма НДС по расч. ставке 20/120
+	TagVAT10110Total               Tag = 1142 // сумма НДС по расч. ставке 10/110
+	TagSumVAT0Total                Tag = 1143 // сумма расчетов с НДС по ставке 0%
+	TagCorrectionCheckCount        Tag = 1144 // количество чеков коррекции
+	TagIncomeCorrectionCounters    Tag = 1145 // счетчики коррекций «приход»
+	TagExpenseCorrectionCounters   Tag = 1146 // счетчики коррекций «расход»
+	TagSelfCorrectionCount         Tag = 1148 // количество самостоятельных корректировок
+	TagOrderedCorrectionCount      Tag = 1149 // количество корректировок по предписанию
+	TagCorrectionVAT20Total        Tag = 1151 // сумма коррекций НДС по ставке 20%
+	TagCorrectionVAT10Total        Tag = 1152 // сумма коррекций НДС по ставке 10%
+	TagCorrectionVAT20120Total     Tag = 1153 // сумма коррекций НДС по расч. ставке 20/120
+	TagCorrectionVAT10110Total     Tag = 1154 // сумма коррекций НДС расч. ставке 10/110
+	TagCorrectionSumVAT0Total      Tag = 1155 // сумма коррекций с НДС по ставке 0%
+	TagFNCounters                  Tag = 1157 // счетчики итогов ФН
+	TagUnsentDocCounters           Tag = 1158 // счетчики итогов непереданных ФД
+	TagNomenclatureCode            Tag = 1162 // код товарной номенклатуры
+	TagProductCode                 Tag = 1163 // код товара
+	TagSupplierPhone               Tag = 1171 // телефон поставщика
+	TagCorrectionType              Tag = 1173 // тип коррекции
+	TagCorrectionBasis             Tag = 1174 // основание для коррекции
+	TagCorrectionDescription       Tag = 1177 // описание коррекции
+	TagCorrectionBasisDate         Tag = 1178 // дата документа основания для коррекции
+	TagCorrectionBasisNumber       Tag = 1179 // номер документа основания для коррекции
+	TagSumNoVATTotal               Tag = 1183 // сумма расчетов без НДС
+	TagCorrectionSumNoVATTotal     Tag = 1184 // сумма коррекций без НДС
+	TagPaymentPlace                Tag = 1187 // место расчетов
+	TagKKTVersion                  Tag = 1188 // версия ККТ
+	TagKKTFFDVersion               Tag = 1189 // версия ФФД ККТ
+	TagFNFFDVersion                Tag = 1190 // версия ФФД ФН
+	TagItemExtraProp               Tag = 1191 // дополнительный реквизит предмета расчета
+	TagCheckExtraProp              Tag = 1192 // дополнительный реквизит чека (БСО)
+	TagGambling                    Tag = 1193 // признак проведения азартных игр
+	TagCycleCounters               Tag = 1194 // счетчики итогов смены
+	TagQRCode                      Tag = 1196 // QR-код
+	TagUnit                        Tag = 1197 // единица измерения предмета расчета
+	TagUnitVAT                     Tag = 1198 // размер НДС за единицу предмета расчета
+	TagVATRate                     Tag = 1199 // ставка НДС
+	TagItemVAT                     Tag = 1200 // сумма НДС за предмет расчета
+	TagChecksTotal                 Tag = 1201 // общая итоговая сумма в чеках (БСО)
+	TagCashierINN                  Tag = 1203 // ИНН кассира
+	TagReregReasons                Tag = 1205 // коды причин изменения сведений о ККТ
+	TagOperatorMessage             Tag = 1206 // сообщение оператора
+	TagExciseTrade                 Tag = 1207 // признак торговли подакцизными товарами
+	TagChecksSite                  Tag = 1208 // сайт чеков
+	TagFFDVersion                  Tag = 1209 // версия ФФД
+	TagPaymentSubject              Tag = 1212 // признак предмета расчета
+	TagFNKeysResource              Tag = 1213 // ресурс ключей ФП
+	TagPaymentMethod               Tag = 1214 // признак способа расчета
+	TagPrepaidSum                  Tag = 1215 // сумма по чеку (БСО) предоплатой (зачетом аванса и (или) предыдущих платежей)
+	TagCreditSum                   Tag = 1216 // сумма по чеку (БСО) постоплатой (в кредит)
+	TagOtherPaymentSum             Tag = 1217 // сумма по чеку (БСО) встречным предоставлением
+	TagPrepaidTotal                Tag = 1218 // итоговая сумма в чеках (БСО) предоплатами (авансами)
+	TagCreditTotal                 Tag = 1219 // итоговая сумма в чеках (БСО) постоплатами (кредитами)
+	TagOtherPaymentTotal           Tag = 1220 // итоговая сумма в чеках (БСО) встречными предоставлениями
+	TagExternPrinter               Tag = 1221 // признак установки принтера в автомате
+	TagItemAgentFlags              Tag = 1222 // признак агента по предмету расчета
+	TagAgentData                   Tag = 1223 // данные агента
+	TagSupplierData                Tag = 1224 // данные поставщика
+	TagSupplierName                Tag = 1225 // наименование поставщика
+	TagSupplierINN                 Tag = 1226 // ИНН поставщика
+	TagCustomer                    Tag = 1227 // покупатель (клиент)
+	TagCustomerINN                 Tag = 1228 // ИНН покупателя (клиента)
+	TagExcise                      Tag = 1229 // акциз
+	TagOriginCountry               Tag = 1230 // код страны происхождения товара
+	TagCustomsDeclaration          Tag = 1231 // номер декларации на товар
+	TagCustomerBirthDate           Tag = 1243 // дата рождения покупателя (клиента)
+	TagCitizenship                 Tag = 1244 // гражданство
+	TagIdentityDocCode             Tag = 1245 // код вида документа, удостоверяющего личность
+	TagIdentityDocData             Tag = 1246 // данные документа, удостоверяющего личность
+	TagCustomerAddress             Tag = 1254 // адрес покупателя (клиента)
+	TagCustomerInfo                Tag = 1256 // сведения о покупателе (клиенте)
+	TagItemIndustryProp            Tag = 1260 // отраслевой реквизит предмета расчета
+	TagCheckIndustryProp           Tag = 1261 // отраслевой реквизит чека
+	TagFOIVID                      Tag = 1262 // идентификатор ФОИВ
+	TagBasisDocDate                Tag = 1263 // дата документа основания
+	TagBasisDocNumber              Tag = 1264 // номер документа основания
+	TagIndustryPropValue           Tag = 1265 // значение отраслевого реквизита
+	TagCheckOperationalProp        Tag = 1270 // операционный реквизит чека
+	TagOperationID                 Tag = 1271 // идентификатор операции
+	TagOperationData               Tag = 1272 // данные операции
+	TagOperationTime               Tag = 1273 // дата, время операции
+	TagFractionalQuantity          Tag = 1291 // дробное количество маркированного товара
+	TagFractionalPart              Tag = 1292 // дробная часть
+	TagNumerator                   Tag = 1293 // числитель
+	TagDenominator                 Tag = 1294 // знаменатель
+	TagProductCodeUnknown          Tag = 1300 // КТ Н
+	TagProductCodeEAN8             Tag = 1301 // КТ EAN-8
+	TagProductCodeEAN13            Tag = 1302 // КТ EAN-13
+	TagProductCodeITF14            Tag = 1303 // КТ ITF-14
+	TagProductCodeGS10             Tag = 1304 // КТ GS1.0
+	TagProductCodeGS1M             Tag = 1305 // КТ GS1.М
+	TagProductCodeKMK              Tag = 1306 // КТ КМК
+	TagProductCodeMI               Tag = 1307 // КТ МИ
+	TagProductCodeEGAIS20          Tag = 1308 // КТ ЕГАИС-2.0
+	TagProductCodeEGAIS30          Tag = 1309 // КТ ЕГАИС-3.0
+	TagProductCodeF1               Tag = 1320 // КТ Ф.1
+	TagProductCodeF2               Tag = 1321 // КТ Ф.2
+	TagProductCodeF3               Tag = 1322 // КТ Ф.3
+	TagProductCodeF4               Tag = 1323 // КТ Ф.4
+	TagProductCodeF5               Tag = 1324 // КТ Ф.5
+	TagProductCodeF6               Tag = 1325 // КТ Ф.6
+	TagMarkCode                    Tag = 2000 // код маркировки
+	TagPlannedItemStatus           Tag = 2003 // планируемый статус товара
+	TagMarkCheckResult             Tag = 2004 // результат проверки КМ
+	TagRequestResults              Tag = 2005 // результаты обработки запроса
+	TagMarkCodeType                Tag = 2100 // тип кода маркировки
+	TagProductID                   Tag = 2101 // идентификатор товара
+	TagMarkProcessingMode          Tag = 2102 // режим обработки кода маркировки
+	TagUnsentNotificationCount     Tag = 2104 // количество непереданных уведомлений
+	TagRequestProcessingCodes      Tag = 2105 // коды обработки запроса
+	TagItemCheckResult             Tag = 2106 // результат проверки сведений о товаре
+	TagMarkedItemsCheckResults     Tag = 2107 // результаты проверки маркированных товаров
+	TagQuantityMeasure             Tag = 2108 // мера количества предмета расчета
+	TagOISMItemStatus              Tag = 2109 // ответ ОИСМ о статусе товара
+	TagAssignedItemStatus          Tag = 2110 // присвоенный статус товара
+	TagNotificationProcessingCodes Tag = 2111 // коды обработки уведомления
+	TagInvalidMarkCodes            Tag = 2112 // признак некорректных кодов маркировки
+	TagInvalidRequests             Tag = 2113 // признак некорректных запросов и уведомлений
+	TagRequestTime                 Tag = 2114 // дата и время запроса
+	TagMarkControlCode             Tag = 2115 // контрольный код КМ
+)
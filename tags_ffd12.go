@@ -0,0 +1,204 @@
+// Maintained by hand, compare with output of script/generate: names follow the official table,
+// print captions follow printable form of devices, notes are not filled yet.
+
+package ru_nalog
+
+// FFD 1.2
+var tagsFFD12 = [...]TagDesc{
+	{DataKindBool, 1001, 1, false, "признак автоматического режима", "АВТОМАТ. РЕЖИМ", ""},
+	{DataKindBool, 1002, 1, false, "признак автономного режима", "АВТОНОМН. РЕЖИМ", ""},
+	{DataKindString, 1005, 256, true, "адрес оператора перевода", "АДР. ОП. ПЕРЕВОДА", ""},
+	{DataKindString, 1008, 64, true, "телефон или электронный адрес покупателя", "ЭЛ. АДР. ПОКУПАТЕЛЯ", ""},
+	{DataKindString, 1009, 256, true, "адрес расчетов", "", ""},
+	{DataKindTime, 1012, 4, false, "дата, время", "", ""},
+	{DataKindString, 1013, 20, true, "заводской номер ККТ", "ЗН ККТ", ""},
+	{DataKindString, 1016, 12, false, "ИНН оператора перевода", "ИНН ОП. ПЕРЕВОДА", ""},
+	{DataKindString, 1017, 12, false, "ИНН ОФД", "ИНН ОФД", ""},
+	{DataKindString, 1018, 12, false, "ИНН пользователя", "ИНН", ""},
+	{DataKindVLN, 1020, 6, true, "сумма расчета, указанного в чеке (БСО)", "ИТОГ", ""},
+	{DataKindString, 1021, 64, true, "кассир", "КАССИР", ""},
+	{DataKindUint, 1022, 1, false, "код ответа ОФД", "", ""},
+	{DataKindFVLN, 1023, 8, true, "количество предмета расчета", "КОЛ.", ""},
+	{DataKindString, 1026, 64, true, "наименование оператора перевода", "ОП. ПЕРЕВОДА", ""},
+	{DataKindString, 1030, 128, true, "наименование предмета расчета", "", ""},
+	{DataKindVLN, 1031, 6, true, "сумма по чеку (БСО) наличными", "НАЛИЧНЫМИ", ""},
+	{DataKindString, 1036, 20, true, "номер автомата", "АВТОМАТ №", ""},
+	{DataKindString, 1037, 20, false, "регистрационный номер ККТ", "РН ККТ", ""},
+	{DataKindUint, 1038, 4, false, "номер смены", "СМЕНА", ""},
+	{DataKindUint, 1040, 4, false, "номер ФД", "ФД", ""},
+	{DataKindString, 1041, 16, false, "номер ФН", "ФН", ""},
+	{DataKindUint, 1042, 4, false, "номер чека за смену", "ЧЕК", ""},
+	{DataKindVLN, 1043, 6, true, "стоимость предмета расчета с учетом скидок и наценок", "СТОИМОСТЬ", ""},
+	{DataKindString, 1044, 24, true, "операция платежного агента", "ОПЕРАЦИЯ АГЕНТА", ""},
+	{DataKindString, 1046, 256, true, "наименование ОФД", "ОФД", ""},
+	{DataKindString, 1048, 256, true, "наименование пользователя", "", ""},
+	{DataKindBool, 1050, 1, false, "признак исчерпания ресурса ФН", "РЕСУРС ФН ИСЧЕРПАН", ""},
+	{DataKindBool, 1051, 1, false, "признак необходимости срочной замены ФН", "ЗАМЕНИТЕ ФН", ""},
+	{DataKindBool, 1052, 1, false, "признак переполнения памяти ФН", "ПАМЯТЬ ФН ПЕРЕПОЛНЕНА", ""},
+	{DataKindBool, 1053, 1, false, "признак превышения времени ожидания ответа ОФД", "ПРЕВЫШЕНО ВРЕМЯ ОЖИДАНИЯ ОФД", ""},
+	{DataKindUint, 1054, 1, false, "признак расчета", "", ""},
+	{DataKindUint, 1055, 1, false, "применяемая система налогообложения", "СНО", ""},
+	{DataKindBool, 1056, 1, false, "признак шифрования", "ШИФРОВАНИЕ", ""},
+	{DataKindUint, 1057, 1, false, "признак агента", "АГЕНТ", ""},
+	{DataKindSTLV, 1059, 1024, true, "предмет расчета", "", ""},
+	{DataKindString, 1060, 256, true, "адрес сайта ФНС", "САЙТ ФНС", ""},
+	{DataKindUint, 1062, 1, false, "системы налогообложения", "СНО", ""},
+	{DataKindSTLV, 1068, 9, true, "сообщение оператора для ФН", "СООБЩЕНИЕ ОПЕРАТОРА", ""},
+	{DataKindString, 1073, 19, true, "телефон платежного агента", "ТЛФ. ПЛАТ. АГЕНТА", ""},
+	{DataKindString, 1074, 19, true, "телефон оператора по приему платежей", "ТЛФ. ОП. ПО ПРИЕМУ ПЛАТЕЖЕЙ", ""},
+	{DataKindString, 1075, 19, true, "телефон оператора перевода", "ТЛФ. ОП. ПЕРЕВОДА", ""},
+	{DataKindBytes, 1077, 6, false, "ФПД", "ФП", ""},
+	{DataKindBytes, 1078, 16, true, "ФПО", "ФПО", ""},
+	{DataKindVLN, 1079, 6, true, "цена за единицу предмета расчета с учетом скидок и наценок", "ЦЕНА", ""},
+	{DataKindVLN, 1081, 6, true, "сумма по чеку (БСО) электронными", "БЕЗНАЛИЧНЫМИ", ""},
+	{DataKindSTLV, 1084, 320, true, "дополнительный реквизит пользователя", "", ""},
+	{DataKindString, 1085, 64, true, "наименование дополнительного реквизита пользователя", "", ""},
+	{DataKindString, 1086, 256, true, "значение дополнительного реквизита пользователя", "", ""},
+	{DataKindUint, 1097, 4, false, "количество непереданных ФД", "НЕПЕРЕДАННЫХ ФД", ""},
+	{DataKindTime, 1098, 4, false, "дата и время первого из непереданных ФД", "ФД НЕ ПЕРЕДАНЫ С", ""},
+	{DataKindUint, 1101, 1, false, "код причины перерегистрации", "КОД ПРИЧИНЫ ПЕРЕРЕГ.", ""},
+	{DataKindVLN, 1102, 6, true, "сумма НДС чека по ставке 20%", "СУММА НДС 20%", ""},
+	{DataKindVLN, 1103, 6, true, "сумма НДС чека по ставке 10%", "СУММА НДС 10%", ""},
+	{DataKindVLN, 1104, 6, true, "сумма расчета по чеку с НДС по ставке 0%", "СУММА С НДС 0%", ""},
+	{DataKindVLN, 1105, 6, true, "сумма расчета по чеку без НДС", "СУММА БЕЗ НДС", ""},
+	{DataKindVLN, 1106, 6, true, "сумма НДС чека по расч. ставке 20/120", "СУММА НДС 20/120", ""},
+	{DataKindVLN, 1107, 6, true, "сумма НДС чека по расч. ставке 10/110", "СУММА НДС 10/110", ""},
+	{DataKindBool, 1108, 1, false, "признак ККТ для расчетов только в Интернет", "ККТ ДЛЯ ИНТЕРНЕТ", ""},
+	{DataKindBool, 1109, 1, false, "признак расчетов за услуги", "УСЛУГИ", ""},
+	{DataKindBool, 1110, 1, false, "признак АС БСО", "БСО", ""},
+	{DataKindUint, 1111, 4, false, "общее количество ФД за смену", "", ""},
+	{DataKindUint, 1116, 4, false, "номер первого непереданного документа", "", ""},
+	{DataKindString, 1117, 64, true, "адрес электронной почты отправителя чека", "ЭЛ. АДР. ОТПРАВИТЕЛЯ", ""},
+	{DataKindUint, 1118, 4, false, "количество кассовых чеков (БСО) за смену", "", ""},
+	{DataKindBool, 1126, 1, false, "признак проведения лотереи", "ЛОТЕРЕЯ", ""},
+	{DataKindSTLV, 1129, 116, true, "счетчики операций «приход»", "", ""},
+	{DataKindSTLV, 1130, 116, true, "счетчики операций «возврат прихода»", "", ""},
+	{DataKindSTLV, 1131, 116, true, "счетчики операций «расход»", "", ""},
+	{DataKindSTLV, 1132, 116, true, "счетчики операций «возврат расхода»", "", ""},
+	{DataKindSTLV, 1133, 216, true, "счетчики операций по чекам коррекции", "", ""},
+	{DataKindUint, 1134, 4, false, "количество чеков (БСО) со всеми признаками расчетов", "", ""},
+	{DataKindUint, 1135, 4, false, "количество чеков по признаку расчетов", "", ""},
+	{DataKindVLN, 1136, 6, true, "итоговая сумма в чеках (БСО) наличными денежными средствами", "", ""},
+	{DataKindVLN, 1138, 6, true, "итоговая сумма в чеках (БСО) электронными средствами платежа", "", ""},
+	{DataKindVLN, 1139, 6, true, "сумма НДС по ставке 20%", "", ""},
+	{DataKindVLN, 1140, 6, true, "сумма НДС по ставке 10%", "", ""},
+	{DataKindVLN, 1141, 6, true, "сумма НДС по расч. ставке 20/120", "", ""},
+	{DataKindVLN, 1142, 6, true, "сумма НДС по расч. ставке 10/110", "", ""},
+	{DataKindVLN, 1143, 6, true, "сумма расчетов с НДС по ставке 0%", "", ""},
+	{DataKindUint, 1144, 4, false, "количество чеков коррекции", "", ""},
+	{DataKindSTLV, 1145, 100, true, "счетчики коррекций «приход»", "", ""},
+	{DataKindSTLV, 1146, 100, true, "счетчики коррекций «расход»", "", ""},
+	{DataKindUint, 1148, 4, false, "количество самостоятельных корректировок", "", ""},
+	{DataKindUint, 1149, 4, false, "количество корректировок по предписанию", "", ""},
+	{DataKindVLN, 1151, 6, true, "сумма коррекций НДС по ставке 20%", "", ""},
+	{DataKindVLN, 1152, 6, true, "сумма коррекций НДС по ставке 10%", "", ""},
+	{DataKindVLN, 1153, 6, true, "сумма коррекций НДС по расч. ставке 20/120", "", ""},
+	{DataKindVLN, 1154, 6, true, "сумма коррекций НДС расч. ставке 10/110", "", ""},
+	{DataKindVLN, 1155, 6, true, "сумма коррекций с НДС по ставке 0%", "", ""},
+	{DataKindSTLV, 1157, 708, true, "счетчики итогов ФН", "", ""},
+	{DataKindSTLV, 1158, 708, true, "счетчики итогов непереданных ФД", "", ""},
+	{DataKindSTLV, 1163, 1024, true, "код товара", "", ""},
+	{DataKindString, 1171, 19, true, "телефон поставщика", "", ""},
+	{DataKindBool, 1173, 1, false, "тип коррекции", "ТИП КОРРЕКЦИИ", ""},
+	{DataKindSTLV, 1174, 292, true, "основание для коррекции", "ОСНОВАНИЕ ДЛЯ КОРР.", ""},
+	{DataKindString, 1177, 256, true, "описание коррекции", "", ""},
+	{DataKindTime, 1178, 4, false, "дата документа основания для коррекции", "ДАТА ДОК. ОСН.", ""},
+	{DataKindString, 1179, 32, true, "номер документа основания для коррекции", "НОМЕР ДОК. ОСН.", ""},
+	{DataKindVLN, 1183, 6, true, "сумма расчетов без НДС", "", ""},
+	{DataKindVLN, 1184, 6, true, "сумма коррекций без НДС", "", ""},
+	{DataKindString, 1187, 256, true, "место расчетов", "МЕСТО РАСЧЕТОВ", ""},
+	{DataKindString, 1188, 8, true, "версия ККТ", "ВЕР. ККТ", ""},
+	{DataKindUint, 1189, 1, false, "версия ФФД ККТ", "ФФД ККТ", ""},
+	{DataKindUint, 1190, 1, false, "версия ФФД ФН", "ФФД ФН", ""},
+	{DataKindString, 1191, 64, true, "дополнительный реквизит предмета расчета", "", ""},
+	{DataKindString, 1192, 16, true, "дополнительный реквизит чека (БСО)", "ДОП. РЕКВИЗИТ", ""},
+	{DataKindBool, 1193, 1, false, "признак проведения азартных игр", "АЗАРТНЫЕ ИГРЫ", ""},
+	{DataKindSTLV, 1194, 708, true, "счетчики итогов смены", "", ""},
+	{DataKindString, 1196, 256, true, "QR-код", "", ""},
+	{DataKindString, 1197, 16, true, "единица измерения предмета расчета", "ЕД. ИЗМ.", ""},
+	{DataKindVLN, 1198, 6, true, "размер НДС за единицу предмета расчета", "", ""},
+	{DataKindUint, 1199, 1, false, "ставка НДС", "", ""},
+	{DataKindVLN, 1200, 6, true, "сумма НДС за предмет расчета", "СУММА НДС", ""},
+	{DataKindVLN, 1201, 6, true, "общая итоговая сумма в чеках (БСО)", "", ""},
+	{DataKindString, 1203, 12, false, "ИНН кассира", "ИНН КАССИРА", ""},
+	{DataKindUint, 1205, 4, false, "коды причин изменения сведений о ККТ", "КОДЫ ПРИЧИН ИЗМ.", ""},
+	{DataKindUint, 1206, 1, false, "сообщение оператора", "", ""},
+	{DataKindBool, 1207, 1, false, "признак торговли подакцизными товарами", "ПОДАКЦИЗНЫЕ ТОВАРЫ", ""},
+	{DataKindString, 1208, 256, true, "сайт чеков", "", ""},
+	{DataKindUint, 1209, 1, false, "версия ФФД", "ФФД", ""},
+	{DataKindUint, 1212, 1, false, "признак предмета расчета", "", ""},
+	{DataKindUint, 1213, 2, false, "ресурс ключей ФП", "", ""},
+	{DataKindUint, 1214, 1, false, "признак способа расчета", "", ""},
+	{DataKindVLN, 1215, 6, true, "сумма по чеку (БСО) предоплатой (зачетом аванса и (или) предыдущих платежей)", "ПРЕДВАРИТЕЛЬНАЯ ОПЛАТА (АВАНС)", ""},
+	{DataKindVLN, 1216, 6, true, "сумма по чеку (БСО) постоплатой (в кредит)", "ПОСЛЕДУЮЩАЯ ОПЛАТА (КРЕДИТ)", ""},
+	{DataKindVLN, 1217, 6, true, "сумма по чеку (БСО) встречным предоставлением", "ИНАЯ ФОРМА ОПЛАТЫ", ""},
+	{DataKindVLN, 1218, 6, true, "итоговая сумма в чеках (БСО) предоплатами (авансами)", "", ""},
+	{DataKindVLN, 1219, 6, true, "итоговая сумма в чеках (БСО) постоплатами (кредитами)", "", ""},
+	{DataKindVLN, 1220, 6, true, "итоговая сумма в чеках (БСО) встречными предоставлениями", "", ""},
+	{DataKindBool, 1221, 1, false, "признак установки принтера в автомате", "ПРИНТЕР В АВТОМАТЕ", ""},
+	{DataKindUint, 1222, 1, false, "признак агента по предмету расчета", "АГЕНТ", ""},
+	{DataKindSTLV, 1223, 512, true, "данные агента", "", ""},
+	{DataKindSTLV, 1224, 512, true, "данные поставщика", "", ""},
+	{DataKindString, 1225, 256, true, "наименование поставщика", "ПОСТАВЩИК", ""},
+	{DataKindString, 1226, 12, false, "ИНН поставщика", "ИНН ПОСТАВЩИКА", ""},
+	{DataKindString, 1227, 256, true, "покупатель (клиент)", "ПОКУПАТЕЛЬ", ""},
+	{DataKindString, 1228, 12, false, "ИНН покупателя (клиента)", "ИНН ПОКУПАТЕЛЯ", ""},
+	{DataKindVLN, 1229, 6, true, "акциз", "", ""},
+	{DataKindString, 1230, 3, false, "код страны происхождения товара", "", ""},
+	{DataKindString, 1231, 32, true, "номер декларации на товар", "", ""},
+	{DataKindString, 1243, 10, false, "дата рождения покупателя (клиента)", "", ""},
+	{DataKindString, 1244, 3, false, "гражданство", "", ""},
+	{DataKindString, 1245, 2, false, "код вида документа, удостоверяющего личность", "", ""},
+	{DataKindString, 1246, 64, true, "данные документа, удостоверяющего личность", "", ""},
+	{DataKindString, 1254, 256, true, "адрес покупателя (клиента)", "", ""},
+	{DataKindSTLV, 1256, 1024, true, "сведения о покупателе (клиенте)", "", ""},
+	{DataKindSTLV, 1260, 384, true, "отраслевой реквизит предмета расчета", "", ""},
+	{DataKindSTLV, 1261, 384, true, "отраслевой реквизит чека", "", ""},
+	{DataKindString, 1262, 3, false, "идентификатор ФОИВ", "", ""},
+	{DataKindString, 1263, 10, false, "дата документа основания", "", ""},
+	{DataKindString, 1264, 32, true, "номер документа основания", "", ""},
+	{DataKindString, 1265, 256, true, "значение отраслевого реквизита", "", ""},
+	{DataKindSTLV, 1270, 144, true, "операционный реквизит чека", "", ""},
+	{DataKindUint, 1271, 1, false, "идентификатор операции", "", ""},
+	{DataKindString, 1272, 64, true, "данные операции", "", ""},
+	{DataKindTime, 1273, 4, false, "дата, время операции", "", ""},
+	{DataKindSTLV, 1291, 48, true, "дробное количество маркированного товара", "", ""},
+	{DataKindString, 1292, 24, true, "дробная часть", "", ""},
+	{DataKindVLN, 1293, 8, true, "числитель", "", ""},
+	{DataKindVLN, 1294, 8, true, "знаменатель", "", ""},
+	{DataKindString, 1300, 32, true, "КТ Н", "", ""},
+	{DataKindString, 1301, 8, false, "КТ EAN-8", "", ""},
+	{DataKindString, 1302, 13, false, "КТ EAN-13", "", ""},
+	{DataKindString, 1303, 14, false, "КТ ITF-14", "", ""},
+	{DataKindString, 1304, 38, true, "КТ GS1.0", "", ""},
+	{DataKindString, 1305, 200, true, "КТ GS1.М", "", ""},
+	{DataKindString, 1306, 38, true, "КТ КМК", "", ""},
+	{DataKindString, 1307, 20, true, "КТ МИ", "", ""},
+	{DataKindString, 1308, 33, true, "КТ ЕГАИС-2.0", "", ""},
+	{DataKindString, 1309, 14, true, "КТ ЕГАИС-3.0", "", ""},
+	{DataKindString, 1320, 32, true, "КТ Ф.1", "", ""},
+	{DataKindString, 1321, 32, true, "КТ Ф.2", "", ""},
+	{DataKindString, 1322, 32, true, "КТ Ф.3", "", ""},
+	{DataKindString, 1323, 32, true, "КТ Ф.4", "", ""},
+	{DataKindString, 1324, 32, true, "КТ Ф.5", "", ""},
+	{DataKindString, 1325, 32, true, "КТ Ф.6", "", ""},
+	{DataKindString, 2000, 256, true, "код маркировки", "", ""},
+	{DataKindUint, 2003, 1, false, "планируемый статус товара", "", ""},
+	{DataKindUint, 2004, 1, false, "результат проверки КМ", "", ""},
+	{DataKindUint, 2005, 1, false, "результаты обработки запроса", "", ""},
+	{DataKindUint, 2100, 1, false, "тип кода маркировки", "", ""},
+	{DataKindString, 2101, 255, true, "идентификатор товара", "", ""},
+	{DataKindUint, 2102, 1, false, "режим обработки кода маркировки", "", ""},
+	{DataKindUint, 2104, 4, false, "количество непереданных уведомлений", "", ""},
+	{DataKindUint, 2105, 1, false, "коды обработки запроса", "", ""},
+	{DataKindUint, 2106, 1, false, "результат проверки сведений о товаре", "", ""},
+	{DataKindUint, 2107, 1, false, "результаты проверки маркированных товаров", "", ""},
+	{DataKindUint, 2108, 1, false, "мера количества предмета расчета", "", ""},
+	{DataKindUint, 2109, 1, false, "ответ ОИСМ о статусе товара", "", ""},
+	{DataKindUint, 2110, 1, false, "присвоенный статус товара", "", ""},
+	{DataKindUint, 2111, 1, false, "коды обработки уведомления", "", ""},
+	{DataKindUint, 2112, 1, false, "признак некорректных кодов маркировки", "", ""},
+	{DataKindUint, 2113, 1, false, "признак некорректных запросов и уведомлений", "", ""},
+	{DataKindTime, 2114, 4, false, "дата и время запроса", "", ""},
+	{DataKindString, 2115, 4, false, "контрольный код КМ", "", ""},
+}
@@ -1,13 +1,12 @@
 package ru_nalog
 
-//go:generate ./script/generate
-
 import (
 	"fmt"
-	"sort"
-	"strconv"
+	"math"
+	"reflect"
 	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 type Tag uint16
@@ -17,16 +16,16 @@ type TagDesc struct {
 	Tag    Tag
 	Length uint16
 	Varlen bool
+	// From official table, empty for user tags
+	Name         string // "кассир"
+	PrintCaption string // "КАССИР", empty when value is printed without caption
+	Note         string // remarks column
 }
 
-var userTags []TagDesc
-
-// Will stable sort argument, return previous value.
-// Not thread-safe.
+// Replaces user tags in DefaultTags, return previous value.
+// Argument is copied and sorted, safe for concurrent use with FindTag.
 func RegisterTags(ts []TagDesc) (prev []TagDesc) {
-	prev, userTags = userTags, ts
-	sort.SliceStable(userTags, func(i, j int) bool { return userTags[i].Tag < userTags[j].Tag })
-	return prev
+	return DefaultTags.Register(ts)
 }
 
 func searchSortedTagDesc(tag Tag, xs []TagDesc) *TagDesc {
@@ -49,15 +48,14 @@ func searchSortedTagDesc(tag Tag, xs []TagDesc) *TagDesc {
 }
 
 // Searches in user tags (RegisterTags) first, then in builtin tags.
-// Not thread-safe.
+// Safe for concurrent use, see TagRegistry.
 func FindTag(tag Tag) *TagDesc {
-	// very likely shortcut
-	if userTags != nil {
-		if u := searchSortedTagDesc(tag, userTags); u != nil {
-			return u
-		}
-	}
-	return searchSortedTagDesc(tag, builtinTags[:])
+	return DefaultTags.Find(tag)
+}
+
+// Searches by Name ignoring case, see TagRegistry.FindByName.
+func FindTagByName(name string) *TagDesc {
+	return DefaultTags.FindByName(name)
 }
 
 type TLV struct {
@@ -65,18 +63,12 @@ type TLV struct {
 	Caption   string
 	Printable string
 	value     interface{}
+	tags      *TagRegistry // for AppendNew, nil means DefaultTags
 }
 
+// Uses DefaultTags, see TagRegistry.NewTLV.
 func NewTLV(tag Tag) *TLV {
-	desc := FindTag(tag)
-	if desc == nil {
-		return nil
-	}
-	tlv := &TLV{TagDesc: *desc}
-	if tlv.Kind == DataKindSTLV {
-		tlv.value = make([]TLV, 0, 8)
-	}
-	return tlv
+	return DefaultTags.NewTLV(tag)
 }
 
 func (self *TLV) Children() []TLV {
@@ -86,7 +78,8 @@ func (self *TLV) Children() []TLV {
 	if self.TagDesc.Kind != DataKindSTLV {
 		return nil
 	}
-	return self.value.([]TLV)
+	list, _ := self.value.([]TLV)
+	return list
 }
 
 func (self *TLV) Err() error {
@@ -102,32 +95,73 @@ func (self *TLV) Err() error {
 func (self *TLV) Value() interface{} { return self.value }
 
 func (self *TLV) Append(n *TLV) *TLV {
-	if self == nil {
+	if self == nil || n == nil {
 		return nil
 	}
 	if self.TagDesc.Kind != DataKindSTLV {
 		return nil
 	}
-	list := self.value.([]TLV)
+	list, ok := self.value.([]TLV)
+	if !ok {
+		return nil
+	}
 	list = append(list, *n)
 	self.value = list
 	n2 := &list[len(list)-1]
 	return n2
 }
 
+// Returns nil for unknown tag or if self is not STLV. Panics on unhandled value type, see AppendNewE.
 func (self *TLV) AppendNew(tag Tag, value interface{}) *TLV {
-	n := NewTLV(tag)
+	t, err := self.AppendNewE(tag, value)
+	if _, ok := err.(*TypeError); ok {
+		panic(err)
+	}
+	return t
+}
+
+// Creates child with value, see TrySetValue. On value error child is still appended
+// and returned together with error, so Err() reports it later like with AppendNew.
+func (self *TLV) AppendNewE(tag Tag, value interface{}) (*TLV, error) {
+	if self == nil {
+		return nil, fmt.Errorf("TLV(nil).AppendNewE #%d", tag)
+	}
+	return self.appendNew(self.tags, tag, value)
+}
+
+func (self *TLV) appendNew(tags *TagRegistry, tag Tag, value interface{}) (*TLV, error) {
+	if self.Kind != DataKindSTLV {
+		return nil, &NotSTLVError{Op: "AppendNewE", Tag: self.Tag, Kind: self.Kind}
+	}
+	n := tags.NewTLV(tag)
+	if n == nil {
+		return nil, &UnknownTagError{Tag: tag}
+	}
+	var err error
 	if value != nil {
-		n.SetValue(value)
+		err = n.TrySetValue(value)
 	}
-	return self.Append(n)
+	return self.Append(n), err
 }
 
+// Panics on unhandled value type, see TrySetValue.
 func (self *TLV) SetValue(value interface{}) {
+	if err := self.TrySetValue(value); err != nil {
+		if _, ok := err.(*TypeError); ok {
+			panic(err)
+		}
+	}
+}
+
+// Converts value according to TagDesc and stores it, on error stores and returns error,
+// which is also available from Err(). Unhandled value type is *TypeError.
+func (self *TLV) TrySetValue(value interface{}) error {
 	self.value = nil
 	switch self.TagDesc.Kind {
 	case DataKindBool:
-		self.value = value.(bool)
+		if x, ok := value.(bool); ok {
+			self.value = x
+		}
 	case DataKindBytes:
 		if x, ok := value.([]byte); ok {
 			self.value = x
@@ -136,22 +170,7 @@ func (self *TLV) SetValue(value interface{}) {
 			self.value = []byte(x)
 		}
 	case DataKindFVLN:
-		switch x := value.(type) {
-		case float32:
-			self.value = float64(x)
-		case float64:
-			self.value = x
-		case string:
-			if f, err := strconv.ParseFloat(x, 64); err != nil {
-				self.value = err
-			} else {
-				self.value = f
-			}
-		default:
-			if n, ok := toUint64(value); ok {
-				self.value = float64(n)
-			}
-		}
+		self.value = toDecimal(value)
 	case DataKindSTLV:
 		if n, ok := value.([]TLV); ok {
 			self.value = n
@@ -162,33 +181,106 @@ func (self *TLV) SetValue(value interface{}) {
 		self.value = toDt(value)
 	case DataKindUint:
 		if n, ok := toUint64(value); ok {
-			self.value = uint32(n)
+			self.value = n
+			if err := checkEnumType(self.Tag, value); err != nil {
+				self.value = err
+			}
 		}
 	case DataKindVLN:
-		self.value = toVLN(value, self.TagDesc.Length)
+		self.value = toVLN(value)
 	}
 	if self.value == nil {
-		self.value = fmt.Errorf("SetValue unhandled kind=%s value=%#v", self.TagDesc.Kind.String(), value)
-		panic(self.value)
+		self.value = &TypeError{Op: "SetValue", Tag: self.Tag, Kind: self.Kind, Value: value}
+	}
+	if _, isErr := self.value.(error); !isErr {
+		self.value = self.enforceLength(self.value)
 	}
+	return self.Err()
 }
 
-func (self *TLV) Bytes() []byte {
-	return self.value.([]byte)
+// Value does not fit TagDesc.Length, SetValue stores it as TLV error.
+// Length is counted in characters for strings (one byte each in CP866), in bytes otherwise.
+type LengthError struct {
+	Tag    Tag
+	Length int
+	Max    uint16
+	Fixed  bool
+}
+
+func (e *LengthError) Error() string {
+	if e.Fixed {
+		return fmt.Sprintf("tag=%d length=%d fixed=%d", e.Tag, e.Length, e.Max)
+	}
+	return fmt.Sprintf("tag=%d length=%d max=%d", e.Tag, e.Length, e.Max)
+}
+
+// Checks converted value against TagDesc, pads fixed strings with spaces as FixedString() expects.
+// Returns value to store or error.
+func (self *TLV) enforceLength(v interface{}) interface{} {
+	length := 0
+	switch self.Kind {
+	case DataKindString:
+		s := v.(string)
+		length = utf8.RuneCountInString(s)
+		if !self.Varlen && length < int(self.Length) {
+			return s + strings.Repeat(" ", int(self.Length)-length)
+		}
+	case DataKindBytes:
+		// fixed byte[] is checked by MarshalBinary, devices report them in print format
+		if !self.Varlen {
+			return v
+		}
+		length = len(v.([]byte))
+	case DataKindFVLN:
+		length = 1 + vlnLength(v.(Decimal).Mantissa)
+	case DataKindTime:
+		if unix := wallClockUnix(v.(time.Time)); unix < 0 || unix > math.MaxUint32 {
+			return fmt.Errorf("tag=%d unixtime=%d out of range", self.Tag, unix)
+		}
+		return v
+	case DataKindUint:
+		n := v.(uint64)
+		if length = vlnLength(n); length <= int(self.Length) {
+			return uint32(n)
+		}
+	case DataKindVLN:
+		n := v.(uint64)
+		if length = vlnLength(n); length <= int(self.Length) && n <= math.MaxUint32 && self.Length <= 6 {
+			return uint32(n)
+		}
+	default:
+		return v
+	}
+	if length > int(self.Length) {
+		return &LengthError{Tag: self.Tag, Length: length, Max: self.Length, Fixed: !self.Varlen}
+	}
+	return v
+}
+
+// Minimal number of bytes, at least one.
+func vlnLength(n uint64) int {
+	length := 1
+	for n >>= 8; n != 0; n >>= 8 {
+		length++
+	}
+	return length
 }
 
 func (self *TLV) GoString() string {
 	b := strings.Builder{}
 	fmt.Fprintf(&b, "(#%d", self.Tag)
+	if err := self.Err(); err != nil {
+		fmt.Fprintf(&b, " error=%v)", err)
+		return b.String()
+	}
 	switch self.Kind {
 	case DataKindBool:
 		fmt.Fprintf(&b, " %t", self.Bool())
 	case DataKindBytes:
 		fmt.Fprintf(&b, " %x", self.Bytes())
 	case DataKindFVLN:
-		s := fmt.Sprintf("%.f", self.Float64())
 		b.WriteByte(' ')
-		b.WriteString(s)
+		b.WriteString(self.Decimal().String())
 	case DataKindSTLV:
 		cs := self.Children()
 		b.WriteString(" [")
@@ -214,47 +306,119 @@ func (self *TLV) GoString() string {
 	return b.String()
 }
 
-func (self *TLV) String() string {
-	if !self.Varlen {
-		return self.FixedString()
+// Accessors below panic on type mismatch, Try* variants return *TypeError or stored value error.
+
+func (self *TLV) Bytes() []byte { return mustValue(self.TryBytes()).([]byte) }
+
+// Varlen strings as is, fixed strings without padding.
+func (self *TLV) String() string { return mustValue(self.TryString()).(string) }
+
+func (self *TLV) FixedString() string { return mustValue(self.TryFixedString()).(string) }
+
+func (self *TLV) Bool() bool { return mustValue(self.TryBool()).(bool) }
+
+func (self *TLV) Decimal() Decimal { return mustValue(self.TryDecimal()).(Decimal) }
+
+// FVLN as float64, may lose precision, prefer Decimal().
+func (self *TLV) Float64() float64 { return mustValue(self.TryFloat64()).(float64) }
+
+func (self *TLV) Time() time.Time { return mustValue(self.TryTime()).(time.Time) }
+
+func (self *TLV) Uint32() uint32 { return mustValue(self.TryUint32()).(uint32) }
+
+// VLN / byte[] bug
+func (self *TLV) Uint64() uint64 { return mustValue(self.TryUint64()).(uint64) }
+
+func (self *TLV) TryBytes() ([]byte, error) {
+	if b, ok := self.valueOrNil().([]byte); ok {
+		return b, nil
 	}
-	x := toString(self.value)
-	if s, ok := x.(string); ok {
-		return s
+	return nil, self.typeError("Bytes")
+}
+
+func (self *TLV) TryString() (string, error) {
+	if self != nil && !self.Varlen {
+		return self.TryFixedString()
 	}
-	panic(fmt.Sprintf("TLV.String() #%d value=%#v", self.Tag, self.value))
+	if s, ok := toString(self.valueOrNil()).(string); ok {
+		return s, nil
+	}
+	return "", self.typeError("String")
 }
 
-func (self *TLV) FixedString() string {
-	crude := toString(self.value)
-	if s, ok := crude.(string); ok {
-		return strings.TrimRightFunc(s, isSpace)
+func (self *TLV) TryFixedString() (string, error) {
+	if s, ok := toString(self.valueOrNil()).(string); ok {
+		return strings.TrimRightFunc(s, isSpace), nil
 	}
-	panic(fmt.Sprintf("TLV.FixedString() #%d crude=%#v", self.Tag, crude))
+	return "", self.typeError("FixedString")
 }
 
-func (self *TLV) Bool() bool {
-	return self.value.(bool)
+func (self *TLV) TryBool() (bool, error) {
+	if b, ok := self.valueOrNil().(bool); ok {
+		return b, nil
+	}
+	return false, self.typeError("Bool")
 }
 
-func (self *TLV) Float64() float64 {
-	return self.value.(float64)
+func (self *TLV) TryDecimal() (Decimal, error) {
+	if d, ok := self.valueOrNil().(Decimal); ok {
+		return d, nil
+	}
+	return Decimal{}, self.typeError("Decimal")
 }
 
-func (self *TLV) Time() time.Time {
-	return self.value.(time.Time)
+func (self *TLV) TryFloat64() (float64, error) {
+	d, err := self.TryDecimal()
+	if err != nil {
+		return 0, err
+	}
+	return d.Float64(), nil
 }
 
-func (self *TLV) Uint32() uint32 {
-	return self.value.(uint32)
+func (self *TLV) TryTime() (time.Time, error) {
+	if t, ok := self.valueOrNil().(time.Time); ok {
+		return t, nil
+	}
+	return time.Time{}, self.typeError("Time")
 }
 
-// VLN / byte[] bug
-func (self *TLV) Uint64() uint64 {
-	if u64, ok := toUint64(self.value); ok {
-		return u64
+func (self *TLV) TryUint32() (uint32, error) {
+	if n, ok := toUint64(self.valueOrNil()); ok && n <= math.MaxUint32 {
+		return uint32(n), nil
+	}
+	return 0, self.typeError("Uint32")
+}
+
+func (self *TLV) TryUint64() (uint64, error) {
+	if n, ok := toUint64(self.valueOrNil()); ok {
+		return n, nil
+	}
+	return 0, self.typeError("Uint64")
+}
+
+func (self *TLV) valueOrNil() interface{} {
+	if self == nil {
+		return nil
+	}
+	return self.value
+}
+
+// Stored value error if any, otherwise *TypeError.
+func (self *TLV) typeError(op string) error {
+	if self == nil {
+		return fmt.Errorf("TLV(nil).%s()", op)
 	}
-	return self.value.(uint64)
+	if err := self.Err(); err != nil {
+		return err
+	}
+	return &TypeError{Op: op, Tag: self.Tag, Kind: self.Kind, Value: self.value}
+}
+
+func mustValue(v interface{}, err error) interface{} {
+	if err != nil {
+		panic(err)
+	}
+	return v
 }
 
 type FindByTager interface {
@@ -266,8 +430,9 @@ func (self *TLV) FindByTag(tag Tag) *TLV {
 		return self
 	}
 	if self.Kind == DataKindSTLV {
-		for _, child := range self.Children() {
-			if t := child.FindByTag(tag); t != nil {
+		cs := self.Children()
+		for i := range cs {
+			if t := cs[i].FindByTag(tag); t != nil {
 				return t
 			}
 		}
@@ -292,11 +457,17 @@ func toDt(v interface{}) interface{} {
 			return fmt.Errorf("toDt v=%s err=%v", s, err)
 		}
 	} else if n, ok := toUint64(v); ok {
-		return time.Unix(int64(n), 0)
+		return time.Unix(int64(n), 0).UTC()
 	}
 	return fmt.Errorf("toDt v=%q", v)
 }
 
+// FFD unixtime is device wall clock counted as UTC, so location of t only selects wall clock.
+// Binary decoding returns UTC time with the same wall clock.
+func wallClockUnix(t time.Time) int64 {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC).Unix()
+}
+
 func toString(v interface{}) interface{} {
 	if s, ok := v.(string); ok {
 		return s
@@ -312,6 +483,10 @@ func toString(v interface{}) interface{} {
 
 func toUint64(v interface{}) (uint64, bool) {
 	switch n := v.(type) {
+	case uint8:
+		return uint64(n), true
+	case uint16:
+		return uint64(n), true
 	case int32:
 		return uint64(n), true
 	case uint32:
@@ -325,20 +500,20 @@ func toUint64(v interface{}) (uint64, bool) {
 	case uint64:
 		return uint64(n), true
 	default:
+		// named integer types like VATRate
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uint:
+			return rv.Uint(), true
+		}
 		return 0, false
 	}
 }
 
-func toVLN(v interface{}, length uint16) interface{} {
-	var u uint64
+func toVLN(v interface{}) interface{} {
 	// TODO case string: strconv.ParseInt
 	if u64, ok := toUint64(v); ok {
-		u = u64
-	} else {
-		return fmt.Errorf("toVLN v=%q", v)
-	}
-	if length <= 6 {
-		return uint32(u)
+		return u64
 	}
-	return u
+	return fmt.Errorf("toVLN v=%q", v)
 }
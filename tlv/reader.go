@@ -0,0 +1,93 @@
+// Streaming FFD binary TLV encoding, for archives too large to keep in memory.
+package tlv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	ru_nalog "github.com/temoto/ru-nalog-go"
+)
+
+const headerSize = 4
+
+// Reads top level TLVs one at a time. Errors in data are *ru_nalog.DecodeError
+// with Offset counted from the start of stream, reading continues with next TLV.
+type Reader struct {
+	Tags *ru_nalog.TagRegistry // nil means ru_nalog.DefaultTags
+
+	r      io.Reader
+	buf    []byte
+	offset int64
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r, buf: make([]byte, 0, 512)}
+}
+
+// Number of bytes consumed so far, points to the start of next TLV.
+func (r *Reader) Offset() int64 { return r.offset }
+
+// Returns io.EOF only at TLV boundary.
+func (r *Reader) Read() (*ru_nalog.TLV, error) {
+	b, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	t, err := r.Tags.ParseTLV(b)
+	err = r.fixOffset(err)
+	r.offset += int64(len(b))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Reads TLV with document type as tag, see ru_nalog.Doc.UnmarshalBinary.
+func (r *Reader) ReadDoc() (*ru_nalog.Doc, error) {
+	b, err := r.next()
+	if err != nil {
+		return nil, err
+	}
+	d := &ru_nalog.Doc{Tags: r.Tags}
+	err = r.fixOffset(d.UnmarshalBinary(b))
+	r.offset += int64(len(b))
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Returned slice is valid until next call.
+func (r *Reader) next() ([]byte, error) {
+	r.buf = r.buf[:headerSize]
+	if n, err := io.ReadFull(r.r, r.buf); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, r.errorf(0, "read header n=%d err=%v", n, err)
+	}
+	tag := ru_nalog.Tag(binary.LittleEndian.Uint16(r.buf[0:]))
+	length := int(binary.LittleEndian.Uint16(r.buf[2:]))
+	if cap(r.buf) < headerSize+length {
+		buf := make([]byte, headerSize, headerSize+length)
+		copy(buf, r.buf)
+		r.buf = buf
+	}
+	r.buf = r.buf[:headerSize+length]
+	if n, err := io.ReadFull(r.r, r.buf[headerSize:]); err != nil {
+		return nil, r.errorf(tag, "read value length=%d n=%d err=%v", length, n, err)
+	}
+	return r.buf, nil
+}
+
+func (r *Reader) errorf(tag ru_nalog.Tag, format string, args ...interface{}) error {
+	return &ru_nalog.DecodeError{Offset: int(r.offset), Tag: tag, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (r *Reader) fixOffset(err error) error {
+	if de, ok := err.(*ru_nalog.DecodeError); ok {
+		de.Offset += int(r.offset)
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+package tlv
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ru_nalog "github.com/temoto/ru-nalog-go"
+)
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	docs := make([]*ru_nalog.Doc, 0, 3)
+	for i := 1; i <= 3; i++ {
+		d := ru_nalog.NewDoc(uint32(i), ru_nalog.FDCheck)
+		d.AppendNew(1040, i)
+		d.AppendNew(1054, 1)
+		row := d.AppendNew(1059, nil)
+		row.AppendNew(1030, "item")
+		row.AppendNew(1079, 100*i)
+		require.NoError(t, w.WriteDoc(d))
+		docs = append(docs, d)
+	}
+	tlv := ru_nalog.NewTLV(1021)
+	tlv.SetValue("cashier")
+	require.NoError(t, w.Write(tlv))
+	assert.Equal(t, int64(buf.Len()), w.Offset())
+
+	r := NewReader(bytes.NewReader(buf.Bytes()))
+	for _, d := range docs {
+		got, err := r.ReadDoc()
+		require.NoError(t, err)
+		assert.Equal(t, d.String(), got.String())
+	}
+	got, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, "cashier", got.String())
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, w.Offset(), r.Offset())
+}
+
+func TestReaderErrorOffset(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	tlv := ru_nalog.NewTLV(1054)
+	tlv.SetValue(1)
+	require.NoError(t, w.Write(tlv))
+	require.NoError(t, w.Write(tlv))
+	// 1059{1054: 1, 1001: 7 (invalid bool)}
+	buf.Write([]byte{0x23, 0x04, 0x0a, 0x00, 0x1e, 0x04, 0x01, 0x00, 0x01, 0xe9, 0x03, 0x01, 0x00, 0x07})
+
+	r := NewReader(&buf)
+	for i := 0; i < 2; i++ {
+		_, err := r.Read()
+		require.NoError(t, err)
+	}
+	_, err := r.Read()
+	require.Error(t, err)
+	de, ok := err.(*ru_nalog.DecodeError)
+	require.True(t, ok)
+	assert.Equal(t, 10+9, de.Offset)
+	assert.Equal(t, ru_nalog.Tag(1001), de.Tag)
+}
+
+func TestReaderAfterError(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	d := ru_nalog.NewDoc(0, ru_nalog.FDCheck)
+	d.AppendNew(1054, 1)
+	// check{1001: 7 (invalid bool)}
+	buf.Write([]byte{0x03, 0x00, 0x05, 0x00, 0xe9, 0x03, 0x01, 0x00, 0x07})
+	require.NoError(t, w.WriteDoc(d))
+	// 1001: 7, then valid 1054: 1
+	buf.Write([]byte{0xe9, 0x03, 0x01, 0x00, 0x07})
+	buf.Write([]byte{0x1e, 0x04, 0x01, 0x00, 0x01})
+
+	r := NewReader(&buf)
+	_, err := r.ReadDoc()
+	require.IsType(t, &ru_nalog.DecodeError{}, err)
+	assert.Equal(t, 4, err.(*ru_nalog.DecodeError).Offset)
+	assert.Equal(t, int64(9), r.Offset())
+	got, err := r.ReadDoc()
+	require.NoError(t, err)
+	assert.Equal(t, d.String(), got.String())
+
+	start := r.Offset()
+	_, err = r.Read()
+	require.IsType(t, &ru_nalog.DecodeError{}, err)
+	assert.Equal(t, int(start), err.(*ru_nalog.DecodeError).Offset)
+	tlv, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(t, ru_nalog.Tag(1054), tlv.Tag)
+	assert.Equal(t, start+5+5, r.Offset())
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReaderTruncated(t *testing.T) {
+	t.Parallel()
+
+	r := NewReader(bytes.NewReader([]byte{0x1e, 0x04, 0x02, 0x00, 0x01}))
+	_, err := r.Read()
+	require.Error(t, err)
+	de, ok := err.(*ru_nalog.DecodeError)
+	require.True(t, ok)
+	assert.Equal(t, 0, de.Offset)
+	assert.Equal(t, ru_nalog.Tag(1054), de.Tag)
+}
@@ -0,0 +1,43 @@
+package tlv
+
+import (
+	"fmt"
+	"io"
+
+	ru_nalog "github.com/temoto/ru-nalog-go"
+)
+
+// Writes TLVs one at a time, no buffering.
+type Writer struct {
+	w      io.Writer
+	offset int64
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Number of bytes written so far.
+func (w *Writer) Offset() int64 { return w.offset }
+
+func (w *Writer) Write(t *ru_nalog.TLV) error {
+	b, err := t.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("tlv.Write offset=%d err=%v", w.offset, err)
+	}
+	return w.write(b)
+}
+
+func (w *Writer) WriteDoc(d *ru_nalog.Doc) error {
+	b, err := d.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("tlv.WriteDoc offset=%d err=%v", w.offset, err)
+	}
+	return w.write(b)
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"testing"
 	"testing/quick"
 	"time"
@@ -40,6 +41,19 @@ func TestTag(t *testing.T) {
 			F := func(format string) func(interface{}) string {
 				return func(n interface{}) string { return fmt.Sprintf("(#%d "+format+")", tlv.Tag, n) }
 			}
+			// SetValue enforces Length
+			fitUint := func(n uint64) uint64 {
+				if desc.Length < 8 {
+					n &= 1<<(8*desc.Length) - 1
+				}
+				return n
+			}
+			fitString := func(s string) string {
+				if rs := []rune(s); len(rs) > int(desc.Length) {
+					return string(rs[:desc.Length])
+				}
+				return s
+			}
 			check := func(n interface{}, ideq func() bool, gostr func(interface{}) string) bool {
 				tlv.SetValue(n)
 				require.NoError(t, tlv.Err())
@@ -52,24 +66,39 @@ func TestTag(t *testing.T) {
 				Q(func(n bool) bool { return check(n, func() bool { return Eq(n, tlv.Bool()) }, F("%t")) })
 			case DataKindBytes:
 				Q(func(n []byte) bool {
+					if desc.Varlen && len(n) > int(desc.Length) {
+						n = n[:desc.Length]
+					}
 					return check(n, func() bool { return assert.True(t, bytes.Equal(n, tlv.Bytes())) }, F("%x"))
 				})
 				Q(func(n string) bool {
+					if desc.Varlen && len(n) > int(desc.Length) {
+						n = n[:desc.Length]
+					}
 					return check(n, func() bool { return assert.True(t, n == string(tlv.Bytes())) }, F("%x"))
 				})
 			case DataKindFVLN:
-				Q(func(n float64) bool {
-					ns := fmt.Sprintf("%.f", n)
+				Q(func(m uint32, p uint8) bool {
+					n := Decimal{Mantissa: uint64(m), Point: p % 8}
+					return check(n, func() bool { return Eq(n, tlv.Decimal()) }, F("%s"))
+				})
+				Q(func(m uint32, p uint8) bool {
+					d := Decimal{Mantissa: uint64(m), Point: p % 8}
+					n := d.Format(",", " ")
+					return check(n, func() bool { return Eq(d, tlv.Decimal()) },
+						func(interface{}) string { return F("%s")(d) })
+				})
+				Q(func(m uint32) bool {
+					n := float64(m) / 1000
+					ns := strconv.FormatFloat(n, 'f', -1, 64)
 					return check(n, func() bool { return Eq(n, tlv.Float64()) },
 						func(interface{}) string { return F("%s")(ns) })
 				})
-				Q(func(r float64) bool {
-					n := fmt.Sprintf("%.f", r)
-					return check(n, func() bool { return Eq(r, tlv.Float64()) },
-						func(interface{}) string { return F("%s")(n) })
-				})
 			case DataKindString:
-				Q(func(n string) bool { return check(n, func() bool { return Eq(n, tlv.String()) }, F("%s")) })
+				Q(func(n string) bool {
+					n = fitString(n)
+					return check(n, func() bool { return Eq(n, tlv.String()) }, F("%s"))
+				})
 			case DataKindTime:
 				Q(func(r uint32) bool {
 					n := time.Unix(int64(r), 0)
@@ -78,36 +107,33 @@ func TestTag(t *testing.T) {
 				})
 			case DataKindUint:
 				Q(func(n uint) bool {
+					n = uint(fitUint(uint64(n)))
 					return check(n, func() bool { return Eq(uint32(n), tlv.Uint32()) },
 						func(interface{}) string { return F("%x")(uint32(n)) })
 				})
 				Q(func(n uint32) bool {
+					n = uint32(fitUint(uint64(n)))
 					return check(n, func() bool { return Eq(n, tlv.Uint32()) },
 						func(interface{}) string { return F("%x")(n) })
 				})
 				Q(func(n uint64) bool {
+					n = fitUint(n)
 					return check(n, func() bool { return Eq(uint32(n), tlv.Uint32()) },
 						func(interface{}) string { return F("%x")(uint32(n)) })
 				})
 			case DataKindVLN:
-				if desc.Length <= 6 {
-					Q(func(n uint) bool {
-						return check(n, func() bool { return Eq(uint32(n), uint32(tlv.Uint64())) },
-							func(interface{}) string { return F("%d")(uint32(n)) })
-					})
-					Q(func(n uint32) bool {
-						return check(n, func() bool { return Eq(n, uint32(tlv.Uint64())) },
-							func(interface{}) string { return F("%d")(uint32(n)) })
-					})
-					Q(func(n uint64) bool {
-						return check(n, func() bool { return Eq(uint32(n), uint32(tlv.Uint64())) },
-							func(interface{}) string { return F("%d")(uint32(n)) })
-					})
-				} else {
-					Q(func(n uint) bool { return check(n, func() bool { return Eq(n, uint(tlv.Uint64())) }, F("%d")) })
-					Q(func(n uint32) bool { return check(n, func() bool { return Eq(n, uint32(tlv.Uint64())) }, F("%d")) })
-					Q(func(n uint64) bool { return check(n, func() bool { return Eq(n, tlv.Uint64()) }, F("%d")) })
-				}
+				Q(func(n uint) bool {
+					n = uint(fitUint(uint64(n)))
+					return check(n, func() bool { return Eq(n, uint(tlv.Uint64())) }, F("%d"))
+				})
+				Q(func(n uint32) bool {
+					n = uint32(fitUint(uint64(n)))
+					return check(n, func() bool { return Eq(n, uint32(tlv.Uint64())) }, F("%d"))
+				})
+				Q(func(n uint64) bool {
+					n = fitUint(n)
+					return check(n, func() bool { return Eq(n, tlv.Uint64()) }, F("%d"))
+				})
 			default:
 				t.Skipf("not implemented tag=%d kind=%s", desc.Tag, desc.Kind.String())
 				return
@@ -116,6 +142,100 @@ func TestTag(t *testing.T) {
 	}
 }
 
+func TestSetValueLength(t *testing.T) {
+	t.Parallel()
+
+	type Case struct {
+		tag   Tag
+		value interface{}
+		err   string
+	}
+	for _, c := range []Case{
+		{1018, "7725225244", ""},
+		{1018, "772522524412", ""},
+		{1018, "7725225244123", "tag=1018 length=13 fixed=12"},
+		{1030, strings.Repeat("я", 128), ""},
+		{1030, strings.Repeat("я", 129), "tag=1030 length=129 max=128"},
+		{1078, make([]byte, 17), "tag=1078 length=17 max=16"},
+		{1054, 255, ""},
+		{1054, 256, "tag=1054 length=2 fixed=1"},
+		{1020, uint64(1)<<48 - 1, ""},
+		{1020, uint64(1) << 48, "tag=1020 length=7 max=6"},
+		{1023, Decimal{Mantissa: 1<<56 - 1}, ""},
+		{1023, Decimal{Mantissa: 1 << 56}, "tag=1023 length=9 max=8"},
+	} {
+		tlv := NewTLV(c.tag)
+		tlv.SetValue(c.value)
+		if c.err == "" {
+			assert.NoError(t, tlv.Err(), "tag=%d", c.tag)
+			continue
+		}
+		if assert.Error(t, tlv.Err(), "tag=%d", c.tag) {
+			assert.Equal(t, c.err, tlv.Err().Error())
+			_, ok := tlv.Err().(*LengthError)
+			assert.True(t, ok)
+		}
+	}
+
+	// fixed strings are padded to length
+	tlv := NewTLV(1018)
+	tlv.SetValue("7725225244")
+	assert.Equal(t, "7725225244  ", tlv.Value())
+	assert.Equal(t, "7725225244", tlv.String())
+
+	// VLN above uint32 is not truncated
+	tlv = NewTLV(1020)
+	tlv.SetValue(uint64(1) << 40)
+	assert.Equal(t, uint64(1)<<40, tlv.Uint64())
+}
+
+func TestTryAccessors(t *testing.T) {
+	t.Parallel()
+
+	tlv := NewTLV(1001)
+	err := tlv.TrySetValue("yes")
+	if assert.Error(t, err) {
+		assert.IsType(t, &TypeError{}, err)
+	}
+	assert.Equal(t, err, tlv.Err())
+	assert.Panics(t, func() { tlv.SetValue("yes") })
+	_, err = tlv.TryBool()
+	assert.IsType(t, &TypeError{}, err)
+
+	require.NoError(t, tlv.TrySetValue(true))
+	b, err := tlv.TryBool()
+	assert.NoError(t, err)
+	assert.True(t, b)
+	_, err = tlv.TryUint32()
+	assert.Error(t, err)
+	_, err = tlv.TryTime()
+	assert.Error(t, err)
+	assert.Panics(t, func() { tlv.Uint32() })
+
+	tlv = NewTLV(1020)
+	require.NoError(t, tlv.TrySetValue(uint64(1)<<40))
+	_, err = tlv.TryUint32()
+	assert.Error(t, err)
+	n, err := tlv.TryUint64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1)<<40, n)
+	_, err = tlv.TryDecimal()
+	assert.Error(t, err)
+
+	// stored value error is reported by accessors
+	tlv = NewTLV(1023)
+	assert.Error(t, tlv.TrySetValue("bad"))
+	_, err = tlv.TryDecimal()
+	assert.Equal(t, tlv.Err(), err)
+	assert.Contains(t, tlv.GoString(), "error=")
+
+	var nilTLV *TLV
+	_, err = nilTLV.TryString()
+	assert.Error(t, err)
+	_, err = nilTLV.TryBytes()
+	assert.Error(t, err)
+}
+
 // FindTag is called very often
 func BenchmarkFindDescOnlyBuiltin(b *testing.B) {
 	newCheck := func(tag Tag) func(*testing.B) {
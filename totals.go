@@ -0,0 +1,263 @@
+package ru_nalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Computed total does not match value present in document.
+// Sum of payments is reported with Path of payment tags joined by "+" and Tag of the first one.
+type TotalMismatch struct {
+	Tag    Tag
+	Path   string // see childPaths
+	Expect Money
+	Actual Money
+}
+
+func (m TotalMismatch) String() string {
+	return fmt.Sprintf("%s expected=%s actual=%s", m.Path, m.Expect, m.Actual)
+}
+
+// Returned as error by Doc.ComputeTotals and Doc.CheckTotals.
+type TotalMismatches []TotalMismatch
+
+func (ms TotalMismatches) Error() string {
+	ss := make([]string, len(ms))
+	for i, m := range ms {
+		ss[i] = m.String()
+	}
+	return "total mismatches: " + strings.Join(ss, ", ")
+}
+
+// VAT included in sum: sum * num / den.
+var vatFractions = map[VATRate][2]uint64{
+	VAT20:    {20, 120},
+	VAT10:    {10, 110},
+	VAT20120: {20, 120},
+	VAT10110: {10, 110},
+	VAT0:     {0, 1},
+}
+
+// Check level tags accumulating items of given VAT rate: VAT sum for non-zero rates, items sum otherwise.
+var vatTotalTags = []struct {
+	Rate VATRate
+	Tag  Tag
+}{
+	{VAT20, TagCheckVAT20},
+	{VAT10, TagCheckVAT10},
+	{VAT0, TagCheckSumVAT0},
+	{VATNone, TagCheckSumNoVAT},
+	{VAT20120, TagCheckVAT20120},
+	{VAT10110, TagCheckVAT10110},
+}
+
+var paymentTags = []Tag{TagCashSum, TagElectronicSum, TagPrepaidSum, TagCreditSum, TagOtherPaymentSum}
+
+// Fills in missing totals of check with 1059 items:
+// item 1043 = 1079 * 1023 and 1200 VAT from 1199, check 1020, 1102-1107 by VAT rate.
+// VAT is computed with half up rounding, check VAT from sum of items with the same rate.
+// Without payment tags, whole 1020 is added as cash 1031.
+// Totals already present are verified, see CheckTotals. Document is changed only without errors.
+func (d *Doc) ComputeTotals() error { return d.totals(true) }
+
+// Like ComputeTotals, but only verifies present totals including 1198 and payments sum.
+func (d *Doc) CheckTotals() error { return d.totals(false) }
+
+func (d *Doc) totals(fill bool) error {
+	if d == nil {
+		return fmt.Errorf("Doc(nil).totals")
+	}
+	t := totaler{fill: fill, doc: d}
+	cs := d.Props.Children()
+	paths := childPaths("", cs)
+	var total Money
+	byRate := make(map[VATRate]Money, len(vatTotalTags))
+	hasItems := false
+	for i := range cs {
+		if cs[i].Tag != TagItem {
+			continue
+		}
+		hasItems = true
+		sum, rate, err := t.item(&cs[i], paths[i]+"/")
+		if err != nil {
+			return err
+		}
+		if total, err = total.Add(sum); err != nil {
+			return err
+		}
+		if rate != 0 {
+			if byRate[rate], err = byRate[rate].Add(sum); err != nil {
+				return err
+			}
+		}
+	}
+	if !hasItems {
+		return fmt.Errorf("Doc.totals no items (1059)")
+	}
+
+	t.expect(&d.Props, "", TagTotalSum, total)
+	for _, vt := range vatTotalTags {
+		sum, ok := byRate[vt.Rate]
+		if !ok {
+			continue
+		}
+		value := sum
+		if f := vatFractions[vt.Rate]; f[0] != 0 {
+			var err error
+			if value, err = vatOf(sum, f); err != nil {
+				return err
+			}
+		}
+		t.expect(&d.Props, "", vt.Tag, value)
+	}
+	if t.err != nil {
+		return t.err
+	}
+
+	var paid Money
+	var paidTags []string
+	firstPaid := Tag(0)
+	for i := range cs {
+		if !tagIn(cs[i].Tag, paymentTags) {
+			continue
+		}
+		if firstPaid == 0 {
+			firstPaid = cs[i].Tag
+		}
+		paidTags = append(paidTags, strconv.Itoa(int(cs[i].Tag)))
+		m, err := cs[i].TryMoney()
+		if err != nil {
+			return err
+		}
+		if paid, err = paid.Add(m); err != nil {
+			return err
+		}
+	}
+	switch {
+	case firstPaid == 0 && fill:
+		t.expect(&d.Props, "", TagCashSum, total)
+	case firstPaid != 0 && paid != total:
+		t.ms = append(t.ms, TotalMismatch{Tag: firstPaid, Path: strings.Join(paidTags, "+"), Expect: total, Actual: paid})
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if len(t.ms) != 0 {
+		return t.ms
+	}
+	// items go first, appending to document may move their storage
+	for _, a := range t.adds {
+		a.parent.Append(a.tlv)
+	}
+	return nil
+}
+
+type totaler struct {
+	fill bool
+	doc  *Doc
+	ms   TotalMismatches
+	adds []totalAdd // missing totals, appended when all present totals match
+	err  error
+}
+
+type totalAdd struct {
+	parent *TLV
+	tlv    *TLV
+}
+
+// Returns item sum and VAT rate, 0 if 1199 is absent.
+func (t *totaler) item(row *TLV, prefix string) (Money, VATRate, error) {
+	price, err := findChild(row, TagPrice).TryMoney()
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s1079: %v", prefix, err)
+	}
+	qty, err := findChild(row, TagQuantity).TryDecimal()
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s1023: %v", prefix, err)
+	}
+	sum, err := price.MulDecimal(qty)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s1043: %v", prefix, err)
+	}
+	t.expect(row, prefix, TagItemSum, sum)
+
+	var rate VATRate
+	if rt := findChild(row, TagVATRate); rt != nil {
+		if rate, err = rt.TryVATRate(); err == nil {
+			// unknown rate has no check total
+			err = rt.checkEnum()
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s1199: %v", prefix, err)
+		}
+		if f, ok := vatFractions[rate]; ok {
+			vat, err := vatOf(sum, f)
+			if err != nil {
+				return 0, 0, err
+			}
+			t.expect(row, prefix, TagItemVAT, vat)
+			if findChild(row, TagUnitVAT) != nil {
+				unit, err := vatOf(price, f)
+				if err != nil {
+					return 0, 0, err
+				}
+				t.expect(row, prefix, TagUnitVAT, unit)
+			}
+		}
+	}
+	return sum, rate, t.err
+}
+
+// Verifies tag value or prepares it for append when missing and filling.
+func (t *totaler) expect(parent *TLV, prefix string, tag Tag, value Money) {
+	if t.err != nil {
+		return
+	}
+	if found := findChild(parent, tag); found != nil {
+		actual, err := found.TryMoney()
+		if err != nil {
+			t.err = fmt.Errorf("%s%d: %v", prefix, tag, err)
+			return
+		}
+		if actual != value {
+			t.ms = append(t.ms, TotalMismatch{Tag: tag, Path: fmt.Sprintf("%s%d", prefix, tag), Expect: value, Actual: actual})
+		}
+		return
+	}
+	if !t.fill {
+		return
+	}
+	n := t.doc.Tags.NewTLV(tag)
+	if n == nil {
+		t.err = &UnknownTagError{Tag: tag}
+		return
+	}
+	if t.err = n.TrySetValue(value); t.err == nil {
+		t.adds = append(t.adds, totalAdd{parent: parent, tlv: n})
+	}
+}
+
+// sum * f[0] / f[1] rounded half up
+func vatOf(sum Money, f [2]uint64) (Money, error) {
+	if f[0] != 0 && uint64(sum) > ^uint64(0)/f[0] {
+		return 0, fmt.Errorf("VAT of %s overflow", sum)
+	}
+	n := uint64(sum) * f[0]
+	q, rem := n/f[1], n%f[1]
+	if rem*2 >= f[1] {
+		q++
+	}
+	return Money(q), nil
+}
+
+// First direct child with tag, pointer into parent storage.
+func findChild(parent *TLV, tag Tag) *TLV {
+	cs := parent.Children()
+	for i := range cs {
+		if cs[i].Tag == tag {
+			return &cs[i]
+		}
+	}
+	return nil
+}
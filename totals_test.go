@@ -0,0 +1,86 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeTotals(t *testing.T) {
+	t.Parallel()
+
+	r := &Receipt{
+		Operation: CalcIncome,
+		Items: []Item{
+			{Name: "a", Price: 1999, Quantity: NewDecimal(1500, 3), VATRate: VAT20, PaymentMethod: MethodFullPayment},
+			{Name: "b", Price: 1001, Quantity: NewDecimal(1, 0), VATRate: VAT20, PaymentMethod: MethodFullPayment},
+			{Name: "c", Price: 500, Quantity: NewDecimal(2, 0), VATRate: VATNone, PaymentMethod: MethodFullPayment},
+		},
+	}
+	d, err := r.Doc()
+	require.NoError(t, err)
+	require.NoError(t, d.ComputeTotals())
+	assert.Equal(t, "Doc(#0 Type=3 Props=[(#1054 1) "+
+		"(#1059 [(#1030 a) (#1079 1999) (#1023 1.500) (#1199 1) (#1214 4) (#1043 2999) (#1200 500)]) "+
+		"(#1059 [(#1030 b) (#1079 1001) (#1023 1) (#1199 1) (#1214 4) (#1043 1001) (#1200 167)]) "+
+		"(#1059 [(#1030 c) (#1079 500) (#1023 2) (#1199 6) (#1214 4) (#1043 1000)]) "+
+		"(#1020 5000) (#1102 667) (#1105 1000) (#1031 5000)])", d.String())
+	assert.NoError(t, d.CheckTotals())
+	assert.NoError(t, d.ComputeTotals(), "idempotent")
+
+	// device reported values are verified
+	d.Props.Children()[1].Children()[5].SetValue(Money(3000))
+	err = d.CheckTotals()
+	if ms, ok := err.(TotalMismatches); assert.True(t, ok) {
+		assert.Equal(t, TotalMismatches{{Tag: 1043, Path: "1059[0]/1043", Expect: 2999, Actual: 3000}}, ms)
+	}
+
+	// mismatch leaves document unchanged
+	r.Payments = []Payment{{Type: PaymentCash, Sum: 1000}, {Type: PaymentElectronic, Sum: 3999}}
+	d, err = r.Doc()
+	require.NoError(t, err)
+	before := d.String()
+	err = d.ComputeTotals()
+	if ms, ok := err.(TotalMismatches); assert.True(t, ok) {
+		assert.Equal(t, "total mismatches: 1031+1081 expected=50.00 actual=49.99", ms.Error())
+		assert.Equal(t, Tag(1031), ms[0].Tag)
+	}
+	assert.Equal(t, before, d.String())
+
+	// VAT rate unknown here, from FN or OFD
+	r.Payments = nil
+	d, err = r.Doc()
+	require.NoError(t, err)
+	d.Get("1059[2]/1199").SetValue(7)
+	before = d.String()
+	assert.EqualError(t, d.ComputeTotals(), "1059[2]/1199: tag=1199 invalid VATRate value=7")
+	assert.Equal(t, before, d.String())
+
+	d = NewDoc(0, FDCheck)
+	assert.Error(t, d.ComputeTotals())
+	d.AppendNew(1059, nil).AppendNew(1030, "no price")
+	assert.Error(t, d.ComputeTotals())
+}
+
+func TestVATOf(t *testing.T) {
+	t.Parallel()
+
+	for _, c := range []struct {
+		sum    Money
+		rate   VATRate
+		expect Money
+	}{
+		{12000, VAT20, 2000},
+		{100, VAT20, 17},
+		{1, VAT20, 0},
+		{11000, VAT10, 1000},
+		{5, VAT10110, 0},
+		{6, VAT10110, 1},
+		{100, VAT0, 0},
+	} {
+		vat, err := vatOf(c.sum, vatFractions[c.rate])
+		assert.NoError(t, err)
+		assert.Equal(t, c.expect, vat, "sum=%s rate=%s", c.sum, c.rate)
+	}
+}
@@ -33,7 +33,7 @@ type docdata struct {
 	DocType   ru_nalog.DocType `json:"docType,omitempty"`
 	Name      string           `json:"name,omitempty"`
 	MoneyType int              `json:"moneyType"` //ТИП ОПЛАТЫ (1. Наличным, 2. Электронными, 3. Предоплата, 4. Постоплата, 5. Встречное предоставление)
-	Sum       uint64           `json:"sum"`       // Сумма закрытия чека (может быть 0, если без сдачи)
+	Sum       ru_nalog.Money   `json:"sum"`       // Сумма закрытия чека (может быть 0, если без сдачи)
 	Type      int              `json:"type"`      // Тип документа (1. Продажа,2.Возврат продажи, 4. Покупка, 5. Возврат покупки, 7. Коррекция прихода, 9. Коррекция расхода)
 	Props     []Prop           `json:"fiscprops"`
 }
@@ -104,11 +104,15 @@ func (d *docdata) String() string {
 	return doc.String()
 }
 
-func (d *docdata) ToDoc() (*ru_nalog.Doc, error) {
+// Uses ru_nalog.DefaultTags.
+func (d *docdata) ToDoc() (*ru_nalog.Doc, error) { return d.toDoc(nil) }
+
+func (d *docdata) toDoc(tags *ru_nalog.TagRegistry) (*ru_nalog.Doc, error) {
 	fd := ru_nalog.NewDoc(d.DocNumber, d.DocType)
+	fd.Tags = tags
 	errs := make([]error, 0, 8)
 	for _, p := range d.Props {
-		t, err := p.toTLV()
+		t, err := p.toTLV(tags)
 		if err == nil {
 			fd.Props.Append(t)
 		} else {
@@ -138,8 +142,13 @@ func propFromTLV(t ru_nalog.TLV) (Prop, error) {
 	p.Tag = t.Tag
 	children := t.Children()
 	switch {
-	case t.Tag == 1023: // TODO t.Kind==FVLN ?
-		p.Value = fmt.Sprintf("%.3f", t.Float64())
+	case t.Kind == ru_nalog.DataKindFVLN:
+		// umka prints quantity with 3 decimals
+		d := t.Decimal()
+		if d3, err := d.Rescale(3); err == nil {
+			d = d3
+		}
+		p.Value = d.String()
 
 	case children != nil:
 		p.Props = make([]Prop, 0, len(children))
@@ -157,28 +166,16 @@ func propFromTLV(t ru_nalog.TLV) (Prop, error) {
 	return p, nil
 }
 
-func (p *Prop) toTLV() (*ru_nalog.TLV, error) {
+func (p *Prop) toTLV(tags *ru_nalog.TagRegistry) (*ru_nalog.TLV, error) {
 	// log.Printf("prop=%#v", p)
-	switch p.Tag {
-	case 1196: // QR query string
-		t := &ru_nalog.TLV{
-			TagDesc: ru_nalog.TagDesc{
-				Tag:    1196,
-				Kind:   ru_nalog.DataKindString,
-				Varlen: true,
-			},
-		}
-		t.SetValue(p.Value.(string))
-		return t, nil
-	}
-	t := ru_nalog.NewTLV(p.Tag)
+	t := tags.NewTLV(p.Tag)
 	if t == nil {
 		return nil, errors.Errorf("prop=%#v invalid tag", p)
 	}
 	switch t.Kind {
 	case ru_nalog.DataKindSTLV:
 		for _, child := range p.Props {
-			subt, err := child.toTLV()
+			subt, err := child.toTLV(tags)
 			if err != nil {
 				err = errors.Annotatef(err, "prop=%#v", p)
 				return nil, err
@@ -231,15 +228,9 @@ func (p *Prop) toTLV() (*ru_nalog.TLV, error) {
 			panic(fmt.Sprintf("TODO tag=%d %[2]T %[2]v %#[2]v", p.Tag, p.Value))
 		}
 	case ru_nalog.DataKindFVLN:
-		switch pt := p.Value.(type) {
-		case float64:
-			t.SetValue(fmt.Sprintf("%.3f", pt))
-		case string: // umka joke on FVLN (1023), forced print format
-			crude := p.Value.(string)
-			// "1 333,500" -> 1333500
-			crude = strings.Replace(crude, " ", "", -1)
-			crude = strings.Replace(crude, ",", ".", 1)
-			t.SetValue(crude)
+		switch p.Value.(type) {
+		case float64, string: // umka joke on FVLN (1023), forced print format "1 333,500"
+			t.SetValue(p.Value)
 			if err := t.Err(); err != nil {
 				err = errors.Annotatef(err, "prop=%#v", p)
 				return nil, err
@@ -76,6 +76,8 @@ func findCheckEqual(t testing.TB, f ru_nalog.FindByTager, tag ru_nalog.Tag, expe
 		switch expected := expected.(type) {
 		case bool:
 			require.Equal(t, expected, tlv.Bool(), message)
+		case float64:
+			require.Equal(t, expected, tlv.Float64(), message)
 		case time.Time:
 			require.Equal(t, expected.UnixNano(), tlv.Time().UnixNano(), message)
 		case string:
@@ -2,33 +2,35 @@ package umka
 
 import (
 	"fmt"
-	"github.com/juju/errors"
 	"time"
+
+	"github.com/juju/errors"
+	ru_nalog "github.com/temoto/ru-nalog-go"
 )
 
 type Status struct { //nolint:maligned
-	AgentFlags     byte     `json:"agentFlags" fdn:"1057"`
-	AllowGames     bool     `json:"allowGames" fdn:"1193"`
-	AllowLotteries bool     `json:"allowLotteries" fdn:"1126"`
-	AllowServices  bool     `json:"allowServices" fdn:"1109"`
-	AtmNumber      string   `json:"atmNumber" fdn:"1036"`
-	AutomatMode    bool     `json:"automatMode" fdn:"1001"`
-	Cash           uint64   `json:"cash"`
-	CashBoxNumber  uint32   `json:"cashBoxNumber"` // номер ккм в зале
-	Cashier        uint32   `json:"cashier"`       // номер кассира (в текущем режиме)
-	CycleNumber    uint32   `json:"cycleNumber" fdn:"1038"`
-	CycleOpened    string   `json:"cycleOpened"` // дата/время открытия смены в кассе (текущей или последней закрытой) если смен не было — не передается
-	CycleClosed    string   `json:"cycleClosed"` // дата/время закрытия последней смены в кассе если смена открыта — не передается
-	Dt             string   `json:"dt"`          // дата/время сейчас в кассе
-	Email          string   `json:"email"`
-	ExcisableGoods bool     `json:"excisableGoods" fdn:"1207"`
-	ExternPrinter  bool     `json:"externPrinter" fdn:"1221"`
-	FSFDFVersion   byte     `json:"fSFDFVersion" fdn:"1190"` // версия ФФД ФН — из текущих данных фискализации (1 — 1.0, 2 — 1.05, 3 — 1.1 (см ФФД))
-	FDFVersion     byte     `json:"fDFVersion"`              // версия ФФД ККТ — из текущих данных фискализации (1 — 1.0, 2 — 1.05, 3 — 1.1 (см ФФД))
-	Flags          byte     `json:"flags"`                   // Флаги состояния ККМ( ПРИЛОЖЕНИЕ 3)
-	FnsSite        string   `json:"fnsSite" fdn:"1060"`
-	FsNumber       string   `json:"fsNumber" fdn:"1041"` // Номер ФН, с которым была фискализована касса
-	FsStatus       struct { //nolint:maligned
+	AgentFlags     byte           `json:"agentFlags" fdn:"1057"`
+	AllowGames     bool           `json:"allowGames" fdn:"1193"`
+	AllowLotteries bool           `json:"allowLotteries" fdn:"1126"`
+	AllowServices  bool           `json:"allowServices" fdn:"1109"`
+	AtmNumber      string         `json:"atmNumber" fdn:"1036"`
+	AutomatMode    bool           `json:"automatMode" fdn:"1001"`
+	Cash           ru_nalog.Money `json:"cash"`
+	CashBoxNumber  uint32         `json:"cashBoxNumber"` // номер ккм в зале
+	Cashier        uint32         `json:"cashier"`       // номер кассира (в текущем режиме)
+	CycleNumber    uint32         `json:"cycleNumber" fdn:"1038"`
+	CycleOpened    string         `json:"cycleOpened"` // дата/время открытия смены в кассе (текущей или последней закрытой) если смен не было — не передается
+	CycleClosed    string         `json:"cycleClosed"` // дата/время закрытия последней смены в кассе если смена открыта — не передается
+	Dt             string         `json:"dt"`          // дата/время сейчас в кассе
+	Email          string         `json:"email"`
+	ExcisableGoods bool           `json:"excisableGoods" fdn:"1207"`
+	ExternPrinter  bool           `json:"externPrinter" fdn:"1221"`
+	FSFDFVersion   byte           `json:"fSFDFVersion" fdn:"1190"` // версия ФФД ФН — из текущих данных фискализации (1 — 1.0, 2 — 1.05, 3 — 1.1 (см ФФД))
+	FDFVersion     byte           `json:"fDFVersion"`              // версия ФФД ККТ — из текущих данных фискализации (1 — 1.0, 2 — 1.05, 3 — 1.1 (см ФФД))
+	Flags          byte           `json:"flags"`                   // Флаги состояния ККМ( ПРИЛОЖЕНИЕ 3)
+	FnsSite        string         `json:"fnsSite" fdn:"1060"`
+	FsNumber       string         `json:"fsNumber" fdn:"1041"` // Номер ФН, с которым была фискализована касса
+	FsStatus       struct {       //nolint:maligned
 		CycleIsOpen   byte   `json:"cycleIsOpen"`
 		DebugMode     bool   `json:"debugMode"`
 		FsNumber      string `json:"fsNumber"`
@@ -49,30 +51,30 @@ type Status struct { //nolint:maligned
 			State            uint32 `json:"state"` // Состояние обмена с ОФД ( ПРИЛОЖЕНИЕ 5)
 		} `json:"transport"`
 	} `json:"fsStatus"`
-	InternetOnly     bool   `json:"internetOnly" fdn:"1108"`
-	Introductions    uint32 `json:"introductions"`
-	IntroductionsSum uint64 `json:"introductionsSum"`
-	MakeBso          bool   `json:"makeBso"`
-	Model            uint16 `json:"model"`
-	Modelstr         string `json:"modelstr"` // "УМКА-01-ФА"
-	OfdInn           string `json:"ofdInn" fdn:"1017"`
-	OfdName          string `json:"ofdName" fdn:"1046"`
-	OfflineMode      bool   `json:"offlineMode"`
-	PaymentAddress   string `json:"paymentAddress" fdn:"1009"` // "г. Воронеж, ул. Липецкая, д.3"
-	PaymentPlace     string `json:"paymentPlace" fdn:"1187"`   // "ОФИС1"
-	Payouts          uint32 `json:"payouts"`
-	PayoutsSum       uint64 `json:"payoutsSum"`
-	RegCashierInn    string `json:"regCashierInn"`            // "000000000000"
-	RegCashierName   string `json:"regCashierName"`           // "CASHIER 17"
-	RegDate          string `json:"regDate"`                  // дата фискализации "2006-01-02"
-	RegDocNumber     uint64 `json:"regDocNumber"`             // 1
-	RegNumber        string `json:"regNumber" fdn:"1037"`     // "0000000001020321"
-	ShortFlags       uint32 `json:"shortFlags"`               // 3
-	Taxes            uint32 `json:"taxes" fdn:"1055"`         // 15
-	UseEncryption    bool   `json:"useEncryption" fdn:"1056"` // false
-	UserInn          string `json:"userInn" fdn:"1018"`       // "7725225244"
-	UserName         string `json:"userName" fdn:"1048"`      // "ООО ВЕКТОР-М"
-	Serial           string `json:"serial" fdn:"1013"`        // "16999987"
+	InternetOnly     bool           `json:"internetOnly" fdn:"1108"`
+	Introductions    uint32         `json:"introductions"`
+	IntroductionsSum ru_nalog.Money `json:"introductionsSum"`
+	MakeBso          bool           `json:"makeBso"`
+	Model            uint16         `json:"model"`
+	Modelstr         string         `json:"modelstr"` // "УМКА-01-ФА"
+	OfdInn           string         `json:"ofdInn" fdn:"1017"`
+	OfdName          string         `json:"ofdName" fdn:"1046"`
+	OfflineMode      bool           `json:"offlineMode"`
+	PaymentAddress   string         `json:"paymentAddress" fdn:"1009"` // "г. Воронеж, ул. Липецкая, д.3"
+	PaymentPlace     string         `json:"paymentPlace" fdn:"1187"`   // "ОФИС1"
+	Payouts          uint32         `json:"payouts"`
+	PayoutsSum       ru_nalog.Money `json:"payoutsSum"`
+	RegCashierInn    string         `json:"regCashierInn"`            // "000000000000"
+	RegCashierName   string         `json:"regCashierName"`           // "CASHIER 17"
+	RegDate          string         `json:"regDate"`                  // дата фискализации "2006-01-02"
+	RegDocNumber     uint64         `json:"regDocNumber"`             // 1
+	RegNumber        string         `json:"regNumber" fdn:"1037"`     // "0000000001020321"
+	ShortFlags       uint32         `json:"shortFlags"`               // 3
+	Taxes            uint32         `json:"taxes" fdn:"1062"`         // 15
+	UseEncryption    bool           `json:"useEncryption" fdn:"1056"` // false
+	UserInn          string         `json:"userInn" fdn:"1018"`       // "7725225244"
+	UserName         string         `json:"userName" fdn:"1048"`      // "ООО ВЕКТОР-М"
+	Serial           string         `json:"serial" fdn:"1013"`        // "16999987"
 
 	Mode        string
 	XXX_Mode    uint32 `json:"mode"`    // 0:choice 1:reg 2:x-report 3:z-report 4:prog 5:serial 6:fstore 7:aux
@@ -121,6 +123,20 @@ func (s *Status) CycleAge() (time.Duration, error) {
 	return d, nil
 }
 
+// FFD version of KKT, falls back to FN version if KKT did not report it.
+func (s *Status) FFDVersion() ru_nalog.FFDVersion {
+	if v := ru_nalog.FFDVersion(s.FDFVersion); v.Valid() {
+		return v
+	}
+	return ru_nalog.FFDVersion(s.FSFDFVersion)
+}
+
+// Tag descriptors matching FFDVersion(), nil if version is unknown.
+// Intended usage: UmkaConfig.Tags = status.Tags()
+func (s *Status) Tags() *ru_nalog.TagRegistry {
+	return ru_nalog.TagsForVersion(s.FFDVersion())
+}
+
 func (s *Status) IsCycleOpen() bool { return s.FsStatus.CycleIsOpen == 1 }
 
 func (s *Status) FsExpireDate() time.Time {
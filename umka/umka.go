@@ -38,6 +38,7 @@ type UmkaConfig struct {
 	BaseURL   string
 	SecretFun func() (string, string)
 	RT        http.RoundTripper
+	Tags      *ru_nalog.TagRegistry // nil means ru_nalog.DefaultTags
 }
 
 var _ /*type check*/ Umker = &Umka{}
@@ -99,7 +100,7 @@ func (u *Umka) requestDocJSON(method, path string, req *Frame) (*ru_nalog.Doc, e
 	if f.Document.Result != 0 {
 		return nil, errors.Errorf("umka.requestDocJSON result=%d resultDescription=%s req=%s f=%s", f.Document.Result, f.Document.Message.Description, req.String(), f.String())
 	}
-	doc, err := f.Document.Data.ToDoc()
+	doc, err := f.Document.Data.toDoc(u.config.Tags)
 	if err != nil {
 		err = errors.Annotatef(err, "umka.requestDocJSON/ToDoc req=%s f=%#v", req.String(), f.String())
 	}
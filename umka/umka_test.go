@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"net/http"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,6 +40,8 @@ func TestFiscalCheck(t *testing.T) {
 		doc2, err := u.FiscalCheck("TODO_session_id", doc1)
 		require.NoError(t, err)
 		t.Logf(doc2.String())
+		assert.NoError(t, doc2.CheckQR())
+		assert.NoError(t, doc2.CheckTotals())
 	}
 	cases := []struct {
 		name     string
@@ -88,11 +91,40 @@ func TestStatus(t *testing.T) {
 	st, err := u.Status()
 	require.NoError(t, err)
 	// t.Logf("st=%#v", st)
-	assert.Equal(t, uint64(2156099220), st.Cash)
+	assert.Equal(t, ru_nalog.Money(2156099220), st.Cash)
+	assert.Equal(t, "21560992.20", st.Cash.String())
 	assert.True(t, st.IsCycleOpen())
 	assert.Equal(t, uint32(0), st.OfdOfflineCount())
 	assert.Equal(t, "9999078900003063", st.FsNumber)
 	assert.Equal(t, "2020-01-23T15:20:00", st.FsStatus.LastDocDt)
+	assert.Equal(t, ru_nalog.FFD105, st.FFDVersion())
+	assert.NotNil(t, st.Tags())
+
+	// taxes are all systems of registration (1062), tag 1055 holds exactly one of them
+	f, _ := reflect.TypeOf(*st).FieldByName("Taxes")
+	assert.Equal(t, "1062", f.Tag.Get("fdn"))
+	_, err = ru_nalog.NewDoc(0, ru_nalog.FDCheck).Set(1055, ru_nalog.TaxSystem(st.Taxes))
+	assert.IsType(t, &ru_nalog.EnumError{}, err)
+	_, err = ru_nalog.NewDoc(0, ru_nalog.FDRegistration).Set(1062, ru_nalog.TaxSystem(st.Taxes))
+	assert.NoError(t, err)
+
+	doc, err := ru_nalog.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(t, "7725225244", doc.FindByTag(1018).String())
+	assert.Equal(t, ru_nalog.TaxSystem(63), doc.FindByTag(1062).TaxSystem())
+	st2 := &Status{}
+	require.NoError(t, ru_nalog.Unmarshal(doc, st2))
+	assert.Equal(t, st.UserInn, st2.UserInn)
+	assert.Equal(t, st.Taxes, st2.Taxes)
+	assert.Equal(t, st.AgentFlags, st2.AgentFlags)
+	assert.Equal(t, st.FsStatus.Transport.FirstDocNumber, st2.FsStatus.Transport.FirstDocNumber)
+	assert.Equal(t, ru_nalog.Money(0), st2.Cash)
+
+	// device reports zero flags when not an agent
+	st.AgentFlags = 0
+	doc, err = ru_nalog.Marshal(st)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), doc.FindByTag(1057).Uint32())
 }
 
 type mockRT struct {
@@ -0,0 +1,51 @@
+package ru_nalog
+
+import "fmt"
+
+// FFD version code as in tags 1189, 1190, 1209 and umka Status.FDFVersion.
+type FFDVersion uint8
+
+const (
+	FFD10  FFDVersion = 1
+	FFD105 FFDVersion = 2
+	FFD11  FFDVersion = 3
+	FFD12  FFDVersion = 4
+)
+
+var versionNames = [...]string{FFD10: "1.0", FFD105: "1.05", FFD11: "1.1", FFD12: "1.2"}
+
+// Immutable, TagsForVersion returns overlays on top.
+var versionTags = [...]*TagRegistry{
+	FFD10:  NewTagRegistry(nil, tagsFFD10[:]),
+	FFD105: builtinRegistry,
+	FFD11:  NewTagRegistry(nil, tagsFFD11[:]),
+	FFD12:  NewTagRegistry(nil, tagsFFD12[:]),
+}
+
+func (v FFDVersion) Valid() bool { return v >= FFD10 && v <= FFD12 }
+
+func (v FFDVersion) String() string {
+	if !v.Valid() {
+		return fmt.Sprintf("FFDVersion(%d)", v)
+	}
+	return versionNames[v]
+}
+
+// Accepts "1.05" or version code "2".
+func ParseFFDVersion(s string) (FFDVersion, error) {
+	for v := FFD10; v <= FFD12; v++ {
+		if s == versionNames[v] || s == fmt.Sprint(uint8(v)) {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("ParseFFDVersion s=%q unknown", s)
+}
+
+// New registry with tag descriptors of given FFD version, safe to Register on.
+// Returns nil for unknown version.
+func TagsForVersion(v FFDVersion) *TagRegistry {
+	if !v.Valid() {
+		return nil
+	}
+	return NewTagRegistry(versionTags[v], nil)
+}
@@ -0,0 +1,61 @@
+package ru_nalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagsForVersion(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, TagsForVersion(0))
+	assert.Nil(t, TagsForVersion(5))
+
+	type Case struct {
+		tag      Tag
+		versions [4]bool // 1.0 1.05 1.1 1.2
+	}
+	for _, c := range []Case{
+		{1054, [4]bool{true, true, true, true}},
+		{1196, [4]bool{true, true, true, true}},
+		{1199, [4]bool{false, true, true, true}},
+		{1162, [4]bool{false, true, true, false}},
+		{1228, [4]bool{false, false, true, true}},
+		{1256, [4]bool{false, false, false, true}},
+		{2000, [4]bool{false, false, false, true}},
+	} {
+		for i, expect := range c.versions {
+			v := FFD10 + FFDVersion(i)
+			assert.Equal(t, expect, TagsForVersion(v).Find(c.tag) != nil, "tag=%d version=%s", c.tag, v)
+		}
+	}
+
+	// registries are independent
+	r1, r2 := TagsForVersion(FFD12), TagsForVersion(FFD12)
+	r1.Register([]TagDesc{{Kind: DataKindString, Tag: 65003, Length: 8, Varlen: true}})
+	assert.NotNil(t, r1.Find(65003))
+	assert.Nil(t, r2.Find(65003))
+
+	for _, table := range [][]TagDesc{tagsFFD10[:], builtinTags[:], tagsFFD11[:], tagsFFD12[:]} {
+		for i := 1; i < len(table); i++ {
+			require.True(t, table[i-1].Tag < table[i].Tag, "table must be sorted, tag=%d", table[i].Tag)
+		}
+	}
+}
+
+func TestParseFFDVersion(t *testing.T) {
+	t.Parallel()
+
+	for v := FFD10; v <= FFD12; v++ {
+		parsed, err := ParseFFDVersion(v.String())
+		require.NoError(t, err)
+		assert.Equal(t, v, parsed)
+	}
+	v, err := ParseFFDVersion("2")
+	require.NoError(t, err)
+	assert.Equal(t, FFD105, v)
+	_, err = ParseFFDVersion("1.3")
+	assert.Error(t, err)
+}